@@ -0,0 +1,139 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/yanzzp/name-sprout/internal/lint"
+	"github.com/yanzzp/name-sprout/internal/providers"
+)
+
+// runConfig 实现 `namesprout config`，目前只有 validate 一个动作。
+func runConfig(args []string) int {
+	if len(args) == 0 || args[0] != "validate" {
+		fmt.Fprintln(os.Stderr, "用法：namesprout config validate [选项]")
+		return 2
+	}
+	return runConfigValidate(args[1:])
+}
+
+// runConfigValidate 加载配置及其引用的文件，并检查加载阶段无法发现的问题：
+// 提供方类型是否已注册、命名格式与命名类型是否存在。配置有效时返回 0，否则返回 1。
+func runConfigValidate(args []string) int {
+	fs := flag.NewFlagSet("config validate", flag.ExitOnError)
+	var (
+		cfgPath = fs.String("config", "", configFlagUsage)
+		profile = fs.String("profile", "", profileFlagUsage)
+	)
+	_ = fs.Parse(args)
+
+	env, err := loadEnvironment(*cfgPath, *profile)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 1
+	}
+	if err := validateEnvironment(env); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 1
+	}
+
+	cfg := env.cfg
+	fmt.Println("✓ 配置有效")
+	fmt.Printf("配置文件：%s\n", strings.Join(cfg.Sources(), "、"))
+	if cfg.Profile() != "" {
+		fmt.Printf("配置方案：%s\n", cfg.Profile())
+	}
+	for _, name := range env.app.ProviderNames() {
+		settings, _ := cfg.Provider(name)
+		mark := " "
+		if name == cfg.App.DefaultProvider {
+			mark = "*"
+		}
+		model := settings.Model
+		if model == "" {
+			model = "默认模型"
+		}
+		fmt.Printf("%s %s（%s，%s）\n", mark, name, providers.DisplayName(settings.Type), model)
+	}
+	return 0
+}
+
+// validateEnvironment 汇总需要提供方注册表或命名提示才能完成的校验。
+func validateEnvironment(env *environment) error {
+	var errs []error
+	cfg := env.cfg
+
+	limits := make(map[string]int)
+	for _, name := range env.app.ProviderNames() {
+		provider, err := env.app.Provider(name)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("提供方 %q：%w", name, err))
+			continue
+		}
+		if limiter, ok := provider.(providers.CountLimiter); ok {
+			limits[name] = limiter.MaxCount()
+		}
+	}
+
+	// 生成数量不能超过所用提供方单次能返回的上限，否则多出的部分会被截断。
+	// 当前生效的 app.max_suggestions 已在实例化提供方时检查，这里补充检查未启用的配置方案。
+	for _, name := range cfg.ProfileNames() {
+		profile := cfg.Profiles[name]
+		provider := profile.Provider
+		if provider == "" {
+			provider = cfg.App.DefaultProvider
+		}
+		if limit, ok := limits[provider]; ok && profile.Count > limit {
+			errs = append(errs, fmt.Errorf("profiles.%s.count 为 %d，超过提供方 %s 单次最多生成的 %d 个", name, profile.Count, provider, limit))
+		}
+	}
+
+	if _, err := providers.ParseNamingStyle(cfg.App.DefaultNamingStyle); err != nil {
+		errs = append(errs, fmt.Errorf("app.default_naming_style：%w", err))
+	}
+
+	for _, name := range cfg.ProfileNames() {
+		styles := cfg.Profiles[name].Styles
+		for _, kind := range sortedKeys(styles) {
+			style := styles[kind]
+			if _, err := providers.ParseNameKind(kind); err != nil {
+				errs = append(errs, fmt.Errorf("profiles.%s.styles：%w", name, err))
+			} else if _, _, ok := env.prompts.Lookup(style); !ok {
+				errs = append(errs, fmt.Errorf("profiles.%s.styles.%s 的命名格式无效：%s", name, kind, style))
+			}
+		}
+	}
+
+	for _, kind := range sortedKeys(cfg.Lint.Styles) {
+		style := cfg.Lint.Styles[kind]
+		if !isLintKind(kind) {
+			errs = append(errs, fmt.Errorf("lint.styles 中的类别 %q 无效，可选：function、variable、constant", kind))
+		} else if _, _, ok := env.prompts.Lookup(style); !ok {
+			errs = append(errs, fmt.Errorf("lint.styles.%s 的命名格式无效：%s", kind, style))
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+func sortedKeys(m map[string]string) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func isLintKind(raw string) bool {
+	for _, kind := range lint.AllKinds {
+		if string(kind) == raw {
+			return true
+		}
+	}
+	return false
+}
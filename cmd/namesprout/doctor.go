@@ -0,0 +1,225 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"net/url"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/yanzzp/name-sprout/internal/app"
+	"github.com/yanzzp/name-sprout/internal/config"
+	"github.com/yanzzp/name-sprout/internal/providers"
+)
+
+// doctorDescription 为诊断时发起的最小生成请求。
+const doctorDescription = "检查服务是否可用的函数"
+
+// doctorReport 记录单个提供方的诊断结果。
+type doctorReport struct {
+	name     string
+	settings config.ProviderSettings
+	// proxy 为展开密钥引用后实际生效的代理，解析失败时为空且 proxyErr 为 true。
+	proxy    string
+	proxyErr bool
+	model    string
+	warmup   time.Duration
+	generate time.Duration
+	sample   string
+	stage    string
+	err      error
+	class    providers.ErrorClass
+}
+
+// runDoctor 实现 `namesprout doctor`：逐个实例化提供方并执行 Warmup 与一次最小生成，全部通过时返回 0。
+// 单个提供方的密钥或实例化失败记录为该提供方的“实例化”阶段，随后继续诊断其余提供方。
+func runDoctor(args []string) int {
+	fs := flag.NewFlagSet("doctor", flag.ExitOnError)
+	var (
+		cfgPath  = fs.String("config", "", configFlagUsage)
+		profile  = fs.String("profile", "", profileFlagUsage)
+		only     = fs.String("provider", "", "只诊断指定名称的提供方")
+		timeout  = fs.Duration("timeout", 30*time.Second, "单个提供方的诊断超时时间")
+		skipCall = fs.Bool("no-generate", false, "只检查实例化与 Warmup，不发起生成请求")
+	)
+	_ = fs.Parse(args)
+
+	env, err := loadEnvironment(*cfgPath, *profile)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "✗ %s\n  分类：%s（可运行 namesprout config validate 查看完整的检查结果）\n", err, providers.ErrorClassConfig.Label())
+		return 1
+	}
+	fmt.Printf("配置文件：%s\n", strings.Join(env.cfg.Sources(), "、"))
+
+	names := env.app.ProviderNames()
+	if *only != "" {
+		if _, ok := env.cfg.Provider(*only); !ok {
+			fmt.Fprintf(os.Stderr, "未配置提供方 %q，可选：%s\n", *only, strings.Join(names, "、"))
+			return 1
+		}
+		names = []string{*only}
+	}
+
+	failed := 0
+	for _, name := range names {
+		report := diagnoseProvider(env, name, *timeout, !*skipCall)
+		printDoctorReport(report, name == env.cfg.App.DefaultProvider)
+		if report.err != nil {
+			failed++
+		}
+	}
+
+	if failed > 0 {
+		fmt.Printf("\n%d 个提供方未通过诊断。\n", failed)
+		return 1
+	}
+	fmt.Println("\n全部提供方均可用。")
+	return 0
+}
+
+func diagnoseProvider(env *environment, name string, timeout time.Duration, generate bool) doctorReport {
+	settings, _ := env.cfg.Provider(name)
+	report := doctorReport{name: name, settings: settings, model: settings.Model}
+
+	fail := func(stage string, p providers.Provider, err error) doctorReport {
+		report.stage, report.err, report.class = stage, err, classifyProviderError(p, err)
+		return report
+	}
+
+	// 密钥解析与实例化失败只记在该提供方名下，不影响其它提供方的诊断。
+	provider, err := env.app.Provider(name)
+	if err != nil {
+		report.stage, report.err, report.class = "实例化", err, providers.ErrorClassConfig
+		var secretErr *config.SecretError
+		if errors.As(err, &secretErr) {
+			report.class, report.proxyErr = providers.ErrorClassAuth, true
+			return report
+		}
+	}
+	// 密钥解析成功后结果已缓存，这里不会再次执行 !cmd。
+	if resolved, err := env.app.ResolvedSettings(name); err == nil {
+		report.proxy = resolved.Proxy
+	}
+	if report.err != nil {
+		return report
+	}
+	if reporter, ok := provider.(providers.ModelReporter); ok {
+		report.model = reporter.ModelIdentifier()
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	if initializer, ok := provider.(providers.Initializer); ok {
+		start := time.Now()
+		err := initializer.Warmup(ctx)
+		report.warmup = time.Since(start)
+		if err != nil {
+			return fail("Warmup", provider, err)
+		}
+	}
+
+	if !generate {
+		return report
+	}
+	req, err := app.BuildRequest(env.cfg, env.prompts, nil, app.RequestOptions{
+		Description: doctorDescription,
+		Kind:        providers.NameKindFunction,
+		Count:       1,
+	})
+	if err != nil {
+		report.stage, report.err, report.class = "构造请求", err, providers.ErrorClassConfig
+		return report
+	}
+	start := time.Now()
+	names, err := provider.GenerateNames(ctx, req)
+	report.generate = time.Since(start)
+	if err != nil {
+		return fail("生成", provider, err)
+	}
+	if len(names) > 0 {
+		report.sample = names[0]
+	}
+	return report
+}
+
+// classifyProviderError 优先使用提供方自身的错误分类，无法识别时回退到通用分类。
+func classifyProviderError(p providers.Provider, err error) providers.ErrorClass {
+	if classifier, ok := p.(providers.ErrorClassifier); ok {
+		return classifier.ClassifyError(err)
+	}
+	return providers.ClassifyError(err)
+}
+
+func printDoctorReport(r doctorReport, isDefault bool) {
+	mark := "✓"
+	if r.err != nil {
+		mark = "✗"
+	}
+	title := fmt.Sprintf("%s %s（%s）", mark, r.name, providers.DisplayName(r.settings.Type))
+	if isDefault {
+		title += " [默认]"
+	}
+	fmt.Println()
+	fmt.Println(title)
+
+	model := r.model
+	if model == "" {
+		model = "默认模型"
+	}
+	fmt.Printf("  模型：%s\n", model)
+	if r.proxyErr {
+		fmt.Println("  代理：无法确定（配置解析失败）")
+	} else {
+		fmt.Printf("  代理：%s\n", describeProxy(r.proxy))
+	}
+	if r.warmup > 0 {
+		fmt.Printf("  Warmup：%s\n", formatLatency(r.warmup))
+	}
+	if r.generate > 0 {
+		line := fmt.Sprintf("  生成：%s", formatLatency(r.generate))
+		if r.sample != "" {
+			line += "，示例名称 " + r.sample
+		}
+		fmt.Println(line)
+	}
+	if r.err != nil {
+		fmt.Printf("  %s失败：%s\n", r.stage, r.err)
+		fmt.Printf("  分类：%s", r.class.Label())
+		if hint := r.class.Hint(); hint != "" {
+			fmt.Printf("（%s）", hint)
+		}
+		fmt.Println()
+	}
+}
+
+// describeProxy 说明实际生效的代理：配置中的 proxy 优先，否则为标准代理环境变量，均隐藏其中的密码。
+func describeProxy(configured string) string {
+	if configured != "" {
+		return redactProxy(configured) + "（配置）"
+	}
+	for _, key := range []string{"HTTPS_PROXY", "https_proxy", "ALL_PROXY", "all_proxy"} {
+		if value := os.Getenv(key); value != "" {
+			return fmt.Sprintf("%s（环境变量 %s）", redactProxy(value), key)
+		}
+	}
+	return "直连"
+}
+
+func redactProxy(raw string) string {
+	parsed, err := url.Parse(raw)
+	if err != nil {
+		return raw
+	}
+	return parsed.Redacted()
+}
+
+func formatLatency(d time.Duration) string {
+	if d < time.Second {
+		return fmt.Sprintf("%dms", d.Milliseconds())
+	}
+	return fmt.Sprintf("%.2fs", d.Seconds())
+}
@@ -0,0 +1,169 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/yanzzp/name-sprout/internal/app"
+	"github.com/yanzzp/name-sprout/internal/config"
+	"github.com/yanzzp/name-sprout/internal/glossary"
+	"github.com/yanzzp/name-sprout/internal/history"
+	"github.com/yanzzp/name-sprout/internal/prompts"
+)
+
+// configFlagUsage 为各子命令 --config 参数的说明。
+const configFlagUsage = "配置文件路径，未指定时合并 $NAMESPROUT_CONFIG、.namesprout.yaml、~/.config/namesprout/config.yaml 等多层配置"
+
+// profileFlagUsage 为各子命令 --profile 参数的说明。
+const profileFlagUsage = "启用配置文件 profiles 中的命名方案，默认使用 app.profile"
+
+// environment 汇总各个子命令共享的配置、命名提示与术语表。
+type environment struct {
+	cfg      *config.Config
+	prompts  *prompts.NamingPrompts
+	glossary *glossary.Glossary
+	app      *app.App
+	svc      *app.Service
+	history  *history.Store
+}
+
+// loadEnvironment 读取配置及其引用的命名提示与术语表文件，profile 非空时启用对应的配置方案。
+func loadEnvironment(cfgPath, profile string) (*environment, error) {
+	cfg, err := loadConfig(cfgPath, profile)
+	if err != nil {
+		return nil, fmt.Errorf("加载配置失败：%w", err)
+	}
+
+	var promptFile string
+	if cfg.App.NamingPromptFile != "" {
+		promptFile = resolveRelative(cfg, cfg.App.NamingPromptFile)
+	}
+	namingPrompts, err := prompts.LoadNamingPrompts(promptFile)
+	if err != nil {
+		return nil, fmt.Errorf("加载命名提示配置失败：%w", err)
+	}
+
+	var terms *glossary.Glossary
+	if cfg.App.GlossaryFile != "" {
+		terms, err = glossary.Load(resolveRelative(cfg, cfg.App.GlossaryFile))
+		if err != nil {
+			return nil, fmt.Errorf("加载术语表失败：%w", err)
+		}
+	}
+
+	appCtx, err := app.New(cfg)
+	if err != nil {
+		return nil, fmt.Errorf("初始化应用失败：%w", err)
+	}
+
+	store := openHistory(cfg)
+
+	svc, err := app.NewService(appCtx, namingPrompts, terms, store)
+	if err != nil {
+		return nil, fmt.Errorf("初始化应用失败：%w", err)
+	}
+
+	return &environment{
+		cfg:      cfg,
+		prompts:  namingPrompts,
+		glossary: terms,
+		app:      appCtx,
+		svc:      svc,
+		history:  store,
+	}, nil
+}
+
+// openHistory 返回历史存储；无法确定默认路径时返回 nil，即不记录历史。
+func openHistory(cfg *config.Config) *history.Store {
+	if cfg.App.HistoryFile != "" {
+		return history.Open(resolveRelative(cfg, cfg.App.HistoryFile))
+	}
+	path, err := history.DefaultPath()
+	if err != nil {
+		return nil
+	}
+	return history.Open(path)
+}
+
+// resolveRelative 将相对路径解析为相对于配置文件所在目录的路径。
+func resolveRelative(cfg *config.Config, path string) string {
+	if filepath.IsAbs(path) {
+		return path
+	}
+	return filepath.Join(filepath.Dir(cfg.Source()), path)
+}
+
+// loadConfig 读取配置：显式指定 --config 时只使用该文件，否则按 config.Discover 的顺序合并多层配置。
+// 随后启用 profile 指定的配置方案，为空时使用 app.profile。
+func loadConfig(path, profile string) (*config.Config, error) {
+	var (
+		cfg *config.Config
+		err error
+	)
+	if path != "" {
+		cfg, err = config.Load(resolveConfigPath(path))
+	} else {
+		var layers []config.Layer
+		layers, err = config.Discover()
+		if hint := legacyConfigHint(layers); hint != "" {
+			if err != nil {
+				return nil, fmt.Errorf("%w\n%s", err, hint)
+			}
+			fmt.Fprintln(os.Stderr, hint)
+		}
+		if err == nil {
+			cfg, err = config.LoadLayers(layers...)
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := cfg.ApplyProfile(profile); err != nil {
+		return nil, err
+	}
+	return cfg, nil
+}
+
+// legacyConfigHint 检查当前目录下的 config.yaml：早期版本会自动读取该文件，现在只在显式指定时使用。
+// 文件存在、且除仓库级配置外没有找到其它配置（即尚未迁移）时返回迁移提示；
+// 已有用户级配置时不再提示，以免在其它带 config.yaml 的项目中反复输出。
+func legacyConfigHint(layers []config.Layer) string {
+	for _, layer := range layers {
+		if !layer.Repo {
+			return ""
+		}
+	}
+	path, err := filepath.Abs("config.yaml")
+	if err != nil {
+		return ""
+	}
+	if info, err := os.Stat(path); err != nil || info.IsDir() {
+		return ""
+	}
+	return fmt.Sprintf("提示：当前目录下的 config.yaml 不再被自动读取，请通过 --config %s 指定，或运行 namesprout init 生成用户级配置。", path)
+}
+
+// resolveConfigPath 解析显式指定的配置路径，相对路径依次在当前目录与可执行文件目录中查找。
+func resolveConfigPath(path string) string {
+	if filepath.IsAbs(path) {
+		return path
+	}
+
+	if _, err := os.Stat(path); err == nil {
+		return path
+	}
+
+	exePath, err := os.Executable()
+	if err != nil {
+		return path
+	}
+
+	exeDir := filepath.Dir(exePath)
+	candidate := filepath.Join(exeDir, path)
+	if _, err := os.Stat(candidate); err == nil {
+		return candidate
+	}
+
+	return path
+}
@@ -0,0 +1,54 @@
+package main
+
+import (
+	"context"
+	"os"
+	"time"
+
+	"github.com/yanzzp/name-sprout/internal/app"
+	"github.com/yanzzp/name-sprout/internal/collision"
+	"github.com/yanzzp/name-sprout/internal/export"
+)
+
+const exportTimeout = 60 * time.Second
+
+// runExport 跳过 TUI，生成一次候选并按指定格式写出。
+func runExport(env *environment, opts app.RequestOptions, collisions *collision.Index, dropExisting bool, path, rawFormat string) error {
+	format := export.FormatJSON
+	var err error
+	switch {
+	case rawFormat != "":
+		format, err = export.ParseFormat(rawFormat)
+	case path != "-":
+		format, err = export.FormatFromPath(path)
+	}
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), exportTimeout)
+	defer cancel()
+	result, err := env.svc.Suggest(ctx, app.SuggestOptions{RequestOptions: opts, Source: "cli"})
+	if err != nil {
+		return err
+	}
+
+	names := result.Names
+	if dropExisting {
+		names, _ = collisions.Partition(names)
+	}
+	doc := export.NewDocument(result.Request, result.Provider, result.Model, names)
+
+	if path == "-" {
+		return export.Write(os.Stdout, format, doc)
+	}
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	if err := export.Write(f, format, doc); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
@@ -0,0 +1,122 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/yanzzp/name-sprout/internal/clipboard"
+	"github.com/yanzzp/name-sprout/internal/config"
+	"github.com/yanzzp/name-sprout/internal/history"
+)
+
+// runHistory 实现 `namesprout history`：搜索历史名称并可重新复制。
+func runHistory(args []string) int {
+	fs := flag.NewFlagSet("history", flag.ExitOnError)
+	var (
+		cfgPath = fs.String("config", "", configFlagUsage)
+		limit   = fs.Int("limit", 20, "最多显示的名称数量，0 表示不限制")
+		copyIdx = fs.Int("copy", 0, "复制搜索结果中指定序号的名称")
+		asJSON  = fs.Bool("json", false, "以 JSON 输出匹配的历史记录")
+		clip    = fs.String("clipboard", "auto", "复制方式（auto / system / osc52 / stdout）")
+	)
+	fs.Usage = func() {
+		fmt.Fprintln(fs.Output(), "用法：namesprout history [选项] [关键字 ...]")
+		fs.PrintDefaults()
+	}
+	_ = fs.Parse(args)
+
+	// 历史记录不依赖提供方配置，配置无法加载时提示原因并回退到默认路径。
+	var store *history.Store
+	if cfg, err := loadConfig(*cfgPath, ""); err == nil {
+		store = openHistory(cfg)
+	} else {
+		fmt.Fprintf(os.Stderr, "加载配置失败，使用默认历史记录路径（app.history_file 不会生效）：%v\n", err)
+		store = openHistory(&config.Config{})
+	}
+	if store == nil {
+		fmt.Fprintln(os.Stderr, "无法确定历史记录路径。")
+		return 1
+	}
+
+	entries, err := store.List()
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 1
+	}
+	items := history.Search(entries, strings.Join(fs.Args(), " "))
+
+	if *copyIdx > 0 {
+		if *copyIdx > len(items) {
+			fmt.Fprintf(os.Stderr, "序号 %d 超出范围（共 %d 条）。\n", *copyIdx, len(items))
+			return 1
+		}
+		mode, err := clipboard.ParseMode(*clip)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			return 1
+		}
+		item := items[*copyIdx-1]
+		used, err := clipboard.New(mode, os.Stdout).Write(item.Name)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "复制失败：%v\n", err)
+			return 1
+		}
+		_ = store.MarkCopied(item.Entry.ID, item.Name)
+		switch used {
+		case clipboard.ModeStdout:
+			// 名称已写到标准输出，不再追加提示。
+		case clipboard.ModeOSC52:
+			fmt.Fprintf(os.Stderr, "已通过 OSC 52 复制：%s\n", item.Name)
+		default:
+			fmt.Printf("已复制：%s\n", item.Name)
+		}
+		return 0
+	}
+
+	if *limit > 0 && len(items) > *limit {
+		items = items[:*limit]
+	}
+
+	if *asJSON {
+		type jsonItem struct {
+			Name   string         `json:"name"`
+			Copied bool           `json:"copied"`
+			Pinned bool           `json:"pinned"`
+			Entry  *history.Entry `json:"entry"`
+		}
+		list := make([]jsonItem, 0, len(items))
+		for _, item := range items {
+			list = append(list, jsonItem{Name: item.Name, Copied: item.Copied, Pinned: item.Pinned, Entry: item.Entry})
+		}
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		if err := enc.Encode(list); err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			return 1
+		}
+		return 0
+	}
+
+	if len(items) == 0 {
+		fmt.Println("没有匹配的历史记录。")
+		return 0
+	}
+	for i, item := range items {
+		mark := "  "
+		switch {
+		case item.Pinned:
+			mark = "📌"
+		case item.Copied:
+			mark = "✓ "
+		}
+		description := strings.Join(strings.Fields(item.Entry.Description), " ")
+		if runes := []rune(description); len(runes) > 50 {
+			description = string(runes[:50]) + "…"
+		}
+		fmt.Printf("%3d %s %-32s %s  %-8s %s\n", i+1, mark, item.Name, item.Entry.Time.Format("2006-01-02 15:04"), item.Entry.Kind, description)
+	}
+	return 0
+}
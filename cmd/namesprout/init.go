@@ -0,0 +1,249 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"gopkg.in/yaml.v3"
+
+	"github.com/yanzzp/name-sprout/internal/app"
+	"github.com/yanzzp/name-sprout/internal/config"
+	"github.com/yanzzp/name-sprout/internal/prompts"
+	"github.com/yanzzp/name-sprout/internal/providers"
+	"github.com/yanzzp/name-sprout/internal/ui"
+	defaultprompts "github.com/yanzzp/name-sprout/prompts"
+)
+
+// envNamePattern 匹配形如 GEMINI_API_KEY 的环境变量名，用户直接输入变量名时自动写成 ${...} 引用。
+var envNamePattern = regexp.MustCompile(`^[A-Z][A-Z0-9_]*$`)
+
+// initConfig 为 init 写入的配置文件结构，只包含向导收集的字段。
+type initConfig struct {
+	App struct {
+		DefaultProvider    string `yaml:"default_provider"`
+		MaxSuggestions     int    `yaml:"max_suggestions"`
+		DefaultNamingStyle string `yaml:"default_naming_style"`
+		NamingPromptFile   string `yaml:"naming_prompt_file"`
+	} `yaml:"app"`
+	Providers map[string]initProvider `yaml:"providers"`
+}
+
+type initProvider struct {
+	Type   string `yaml:"type"`
+	APIKey string `yaml:"api_key,omitempty"`
+	Model  string `yaml:"model,omitempty"`
+}
+
+// runInit 实现 `namesprout init`：通过交互式向导生成配置文件与命名提示文件。
+func runInit(args []string) int {
+	fs := flag.NewFlagSet("init", flag.ExitOnError)
+	var (
+		path  = fs.String("path", "", "配置文件写入路径，默认 ~/.config/namesprout/config.yaml")
+		force = fs.Bool("force", false, "覆盖已存在的配置文件与命名提示文件")
+	)
+	_ = fs.Parse(args)
+
+	target := *path
+	if target == "" {
+		defaultPath, err := config.UserConfigPath()
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			return 1
+		}
+		target = defaultPath
+	}
+	if _, err := os.Stat(target); err == nil && !*force {
+		fmt.Fprintf(os.Stderr, "配置文件 %s 已存在，如需覆盖请使用 --force。\n", target)
+		return 1
+	}
+
+	model := ui.NewSetupModel(ui.SetupOptions{
+		Target: target,
+		Apply: func(ctx context.Context, answers ui.SetupAnswers) error {
+			if err := writeInitFiles(target, answers, *force); err != nil {
+				return err
+			}
+			if err := validateInitConfig(ctx, target); err != nil {
+				return fmt.Errorf("配置已写入 %s，但校验失败：%w", target, err)
+			}
+			return nil
+		},
+		ListModels: listSetupModels,
+	})
+	if err := tea.NewProgram(model).Start(); err != nil {
+		fmt.Fprintf(os.Stderr, "运行向导失败：%v\n", err)
+		return 1
+	}
+	if err := model.Err(); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 1
+	}
+	if !model.Completed() {
+		return 1
+	}
+	fmt.Printf("已写入 %s\n", target)
+	return 0
+}
+
+// writeInitFiles 写入配置文件，并在同目录的 prompts/naming.yaml 放置内嵌的默认命名提示。
+// 命名提示文件已存在且未指定 force 时保留原文件，以免覆盖用户的修改。
+func writeInitFiles(target string, answers ui.SetupAnswers, force bool) error {
+	dir := filepath.Dir(target)
+	promptPath := filepath.Join(dir, "prompts", "naming.yaml")
+	if err := os.MkdirAll(filepath.Dir(promptPath), 0o755); err != nil {
+		return fmt.Errorf("创建配置目录失败：%w", err)
+	}
+	if _, err := os.Stat(promptPath); force || errors.Is(err, os.ErrNotExist) {
+		naming, err := namingPromptWithStyle(answers.Style)
+		if err != nil {
+			return fmt.Errorf("生成命名提示文件失败：%w", err)
+		}
+		if err := os.WriteFile(promptPath, naming, 0o644); err != nil {
+			return fmt.Errorf("写入命名提示文件失败：%w", err)
+		}
+	}
+
+	apiKey := apiKeyReference(answers.APIKey)
+
+	var cfg initConfig
+	cfg.App.DefaultProvider = answers.ProviderType
+	cfg.App.MaxSuggestions = 5
+	cfg.App.DefaultNamingStyle = string(answers.Style)
+	cfg.App.NamingPromptFile = "prompts/naming.yaml"
+	cfg.Providers = map[string]initProvider{
+		answers.ProviderType: {Type: answers.ProviderType, APIKey: apiKey, Model: answers.Model},
+	}
+
+	var buf bytes.Buffer
+	enc := yaml.NewEncoder(&buf)
+	enc.SetIndent(2)
+	if err := enc.Encode(cfg); err != nil {
+		return fmt.Errorf("生成配置失败：%w", err)
+	}
+	// 配置中可能包含明文密钥，仅允许当前用户读取。
+	if err := os.WriteFile(target, buf.Bytes(), 0o600); err != nil {
+		return fmt.Errorf("写入配置文件失败：%w", err)
+	}
+	return nil
+}
+
+// styledKinds 为向导中所选命名格式生效的命名类型；项目名称沿用内置的 kebab_case。
+var styledKinds = []string{"function", "variable"}
+
+// namingPromptWithStyle 返回内置命名提示，并把 styledKinds 的 default_style 改为向导中选择的格式。
+// 命名类型的 default_style 优先于 app.default_naming_style，只写后者不会生效。
+func namingPromptWithStyle(style providers.NamingStyle) ([]byte, error) {
+	if style == "" {
+		return defaultprompts.Naming, nil
+	}
+	var root yaml.Node
+	if err := yaml.Unmarshal(defaultprompts.Naming, &root); err != nil {
+		return nil, err
+	}
+	kinds := mappingValue(root.Content[0], "kinds")
+	for _, kind := range styledKinds {
+		if field := mappingValue(mappingValue(kinds, kind), "default_style"); field != nil {
+			field.Value = string(style)
+		}
+	}
+
+	var buf bytes.Buffer
+	enc := yaml.NewEncoder(&buf)
+	enc.SetIndent(2)
+	if err := enc.Encode(&root); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// mappingValue 返回映射节点中 key 对应的值节点，不存在时返回 nil。
+func mappingValue(node *yaml.Node, key string) *yaml.Node {
+	if node == nil || node.Kind != yaml.MappingNode {
+		return nil
+	}
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		if node.Content[i].Value == key {
+			return node.Content[i+1]
+		}
+	}
+	return nil
+}
+
+// apiKeyReference 将单独输入的环境变量名转换为 ${NAME} 引用，其余输入原样返回。
+func apiKeyReference(raw string) string {
+	if envNamePattern.MatchString(raw) {
+		return "${" + raw + "}"
+	}
+	return raw
+}
+
+// listSetupModels 用向导中填写的凭据临时创建提供方并列出可用模型，提供方不支持列出模型时返回空列表。
+func listSetupModels(ctx context.Context, answers ui.SetupAnswers) ([]providers.ModelInfo, error) {
+	apiKey, err := config.ExpandValue(apiKeyReference(answers.APIKey))
+	if err != nil {
+		return nil, err
+	}
+	provider, err := providers.New(answers.ProviderType, config.ProviderSettings{Type: answers.ProviderType, APIKey: apiKey})
+	if err != nil {
+		return nil, err
+	}
+	lister, ok := provider.(providers.ModelLister)
+	if !ok {
+		return nil, nil
+	}
+	return lister.ListModels(ctx)
+}
+
+// validateInitConfig 重新加载写入的配置，对默认提供方执行 Warmup，并用所选模型发起一次最小生成请求，
+// 以确认 API Key 与模型名称确实可用；失败时附上错误分类与排查建议。
+func validateInitConfig(ctx context.Context, path string) error {
+	cfg, err := config.Load(path)
+	if err != nil {
+		return err
+	}
+	namingPrompts, err := prompts.LoadNamingPrompts(cfg.App.NamingPromptFile)
+	if err != nil {
+		return err
+	}
+	appCtx, err := app.New(cfg)
+	if err != nil {
+		return err
+	}
+	provider, err := appCtx.Provider(cfg.App.DefaultProvider)
+	if err != nil {
+		return err
+	}
+
+	describe := func(err error) error {
+		class := classifyProviderError(provider, err)
+		if hint := class.Hint(); hint != "" {
+			return fmt.Errorf("%s：%w（%s）", class.Label(), err, hint)
+		}
+		return fmt.Errorf("%s：%w", class.Label(), err)
+	}
+	if initializer, ok := provider.(providers.Initializer); ok {
+		if err := initializer.Warmup(ctx); err != nil {
+			return describe(err)
+		}
+	}
+
+	req, err := app.BuildRequest(cfg, namingPrompts, nil, app.RequestOptions{
+		Description: doctorDescription,
+		Kind:        providers.NameKindFunction,
+		Count:       1,
+	})
+	if err != nil {
+		return err
+	}
+	if _, err := provider.GenerateNames(ctx, req); err != nil {
+		return describe(err)
+	}
+	return nil
+}
@@ -0,0 +1,137 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/yanzzp/name-sprout/internal/app"
+	"github.com/yanzzp/name-sprout/internal/codectx"
+	"github.com/yanzzp/name-sprout/internal/lint"
+	"github.com/yanzzp/name-sprout/internal/providers"
+)
+
+const lintSuggestTimeout = 45 * time.Second
+
+// runLint 实现 `namesprout lint`：发现问题时返回 1，运行出错时返回 2。
+func runLint(args []string) int {
+	fs := flag.NewFlagSet("lint", flag.ExitOnError)
+	var (
+		cfgPath      = fs.String("config", "", configFlagUsage)
+		profile      = fs.String("profile", "", profileFlagUsage)
+		format       = fs.String("format", lint.FormatText, "输出格式（text / json / sarif）")
+		suggest      = fs.Bool("suggest", false, "调用模型为不合规的标识符生成候选名称")
+		suggestLimit = fs.Int("suggest-limit", 20, "最多为多少个问题请求候选名称")
+	)
+	fs.Usage = func() {
+		fmt.Fprintln(fs.Output(), "用法：namesprout lint [选项] [路径 ...]（默认 ./...）")
+		fs.PrintDefaults()
+	}
+	_ = fs.Parse(args)
+	if err := lint.CheckFormat(*format); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 2
+	}
+
+	env, err := loadEnvironment(*cfgPath, *profile)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 2
+	}
+
+	rules, err := lintRules(env)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 2
+	}
+
+	issues, err := lint.Run(fs.Args(), rules)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "lint 执行失败：%v\n", err)
+		return 2
+	}
+
+	if *suggest && len(issues) > 0 {
+		if err := suggestForIssues(env, issues, *suggestLimit); err != nil {
+			fmt.Fprintf(os.Stderr, "生成候选名称失败：%v\n", err)
+		}
+	}
+
+	if err := lint.Write(os.Stdout, *format, issues); err != nil {
+		fmt.Fprintf(os.Stderr, "输出结果失败：%v\n", err)
+		return 2
+	}
+	if len(issues) > 0 {
+		return 1
+	}
+	return 0
+}
+
+// lintRules 依次使用 lint.styles 与配置方案中的 styles，均未配置时按 Go 惯例检查驼峰格式。
+// 命名提示中的 default_style 面向模型生成（可能是 Python 等其它语言的约定），不用于 lint。
+func lintRules(env *environment) (lint.Rules, error) {
+	rules := lint.Rules{
+		Styles:   make(map[lint.Kind]providers.NamingStyle),
+		Glossary: env.glossary,
+	}
+	for _, kind := range lint.AllKinds {
+		if raw, ok := env.cfg.Lint.Styles[string(kind)]; ok {
+			style, _, found := env.prompts.Lookup(raw)
+			if !found {
+				return rules, fmt.Errorf("lint.styles.%s 的命名格式无效：%s", kind, raw)
+			}
+			rules.Styles[kind] = style
+			continue
+		}
+		if kind != lint.KindConstant {
+			if raw := env.cfg.KindStyle(string(kind.NameKind())); raw != "" {
+				style, _, found := env.prompts.Lookup(raw)
+				if !found {
+					return rules, fmt.Errorf("配置方案 %q 中 %s 的命名格式无效：%s", env.cfg.Profile(), kind.NameKind(), raw)
+				}
+				rules.Styles[kind] = style
+				continue
+			}
+		}
+		rules.Styles[kind] = lint.GoStyle
+	}
+	return rules, nil
+}
+
+// suggestForIssues 以标识符所在代码为上下文向默认提供方请求替代名称。
+func suggestForIssues(env *environment, issues []lint.Issue, limit int) error {
+	requested := make(map[string][]string)
+	for i := range issues {
+		if limit <= 0 {
+			break
+		}
+		issue := &issues[i]
+		key := fmt.Sprintf("%s:%d:%d", issue.Path, issue.Line, issue.Column)
+		if names, ok := requested[key]; ok {
+			issue.Suggestions = names
+			continue
+		}
+
+		snippet, err := codectx.Extract(issue.Path, issue.Line)
+		if err != nil {
+			return err
+		}
+		ctx, cancel := context.WithTimeout(context.Background(), lintSuggestTimeout)
+		result, err := env.svc.Suggest(ctx, app.SuggestOptions{RequestOptions: app.RequestOptions{
+			Description: fmt.Sprintf("%s\n\n现有名称 %s 存在问题：%s", snippet.Description(), issue.Name, issue.Message),
+			Kind:        issue.Kind.NameKind(),
+			Style:       string(issue.Style),
+			Count:       3,
+		}, Source: "lint"})
+		cancel()
+		if err != nil {
+			return err
+		}
+		issue.Suggestions = result.Names
+		requested[key] = result.Names
+		limit--
+	}
+	return nil
+}
@@ -0,0 +1,30 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/yanzzp/name-sprout/internal/lsp"
+)
+
+// runLSP 实现 `namesprout lsp`：通过 stdio 运行语言服务器。
+func runLSP(args []string) int {
+	fs := flag.NewFlagSet("lsp", flag.ExitOnError)
+	cfgPath := fs.String("config", "", configFlagUsage)
+	profile := fs.String("profile", "", profileFlagUsage)
+	_ = fs.Parse(args)
+
+	env, err := loadEnvironment(*cfgPath, *profile)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 1
+	}
+
+	if err := lsp.NewServer(env.svc).Run(context.Background(), os.Stdin, os.Stdout); err != nil {
+		fmt.Fprintf(os.Stderr, "LSP 服务异常退出：%v\n", err)
+		return 1
+	}
+	return 0
+}
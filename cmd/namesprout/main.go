@@ -1,6 +1,7 @@
 package main
 
 import (
+	"bytes"
 	"flag"
 	"fmt"
 	"os"
@@ -8,23 +9,54 @@ import (
 	"strings"
 
 	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
 
 	"github.com/yanzzp/name-sprout/internal/app"
-	"github.com/yanzzp/name-sprout/internal/config"
-	"github.com/yanzzp/name-sprout/internal/prompts"
+	"github.com/yanzzp/name-sprout/internal/clipboard"
+	"github.com/yanzzp/name-sprout/internal/codectx"
+	"github.com/yanzzp/name-sprout/internal/collision"
 	"github.com/yanzzp/name-sprout/internal/providers"
 	"github.com/yanzzp/name-sprout/internal/ui"
 )
 
+// subcommands 将子命令名称映射到各自的入口，返回值作为进程退出码。
+var subcommands = map[string]func(args []string) int{
+	"config":  runConfig,
+	"doctor":  runDoctor,
+	"history": runHistory,
+	"init":    runInit,
+	"lint":    runLint,
+	"lsp":     runLSP,
+	"mcp":     runMCP,
+	"models":  runModels,
+	"serve":   runServe,
+}
+
 func main() {
+	if len(os.Args) > 1 {
+		if run, ok := subcommands[os.Args[1]]; ok {
+			os.Exit(run(os.Args[2:]))
+		}
+	}
+
 	var (
-		cfgPath     = flag.String("config", "config.yaml", "配置文件路径")
+		cfgPath     = flag.String("config", "", configFlagUsage)
+		profile     = flag.String("profile", "", profileFlagUsage)
 		disableAlt  = flag.Bool("no-alt-screen", false, "禁用备用屏幕渲染")
 		showVersion = flag.Bool("version", false, "打印版本信息")
 		caseFlag    = flag.String("style", "", "指定命名格式（lowerCamelCase / PascalCase / snake_case / kebab-case）")
 		funcFlag    = flag.Bool("f", false, "生成函数名称")
 		varFlag     = flag.Bool("v", false, "生成变量名称")
 		projectFlag = flag.Bool("p", false, "生成项目名称")
+		fileFlag    = flag.String("file", "", "代码上下文模式：从源码文件提取待命名的符号")
+		lineFlag    = flag.Int("line", 0, "代码上下文模式：符号所在行号（配合 --file 使用）")
+		scanFlag    = flag.String("scan", "", "扫描目录中已存在的标识符并标记冲突的候选（代码上下文模式默认扫描所在目录）")
+		dropFlag    = flag.Bool("drop-existing", false, "直接剔除与已有标识符冲突的候选，而非仅标记")
+		exportFlag  = flag.String("export", "", "不启动 TUI，直接生成并导出到文件（- 表示标准输出）")
+		exportFmt   = flag.String("export-format", "", "导出格式（json / markdown / csv），默认根据文件扩展名推断")
+		exportDir   = flag.String("export-dir", "", "TUI 中按 X 导出时写入的目录，默认当前目录")
+		printFlag   = flag.Bool("print", false, "按 Enter 选定名称后退出并输出到标准输出，界面改为渲染到标准错误")
+		clipFlag    = flag.String("clipboard", "auto", "复制方式（auto / system / osc52 / stdout），auto 在系统剪贴板不可用时回退到 OSC 52")
 	)
 	flag.Parse()
 
@@ -33,6 +65,12 @@ func main() {
 		return
 	}
 
+	clipMode, err := clipboard.ParseMode(*clipFlag)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+
 	modeCount := 0
 	if *funcFlag {
 		modeCount++
@@ -43,48 +81,62 @@ func main() {
 	if *projectFlag {
 		modeCount++
 	}
-	if modeCount != 1 {
+
+	var snippet *codectx.Snippet
+	if *fileFlag != "" {
+		if *lineFlag <= 0 {
+			fmt.Fprintln(os.Stderr, "代码上下文模式需要通过 --line 指定行号。")
+			os.Exit(1)
+		}
+		var err error
+		snippet, err = codectx.Extract(*fileFlag, *lineFlag)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "提取代码上下文失败：%v\n", err)
+			os.Exit(1)
+		}
+	}
+
+	if modeCount > 1 || (modeCount == 0 && (snippet == nil || snippet.Kind == "")) {
 		fmt.Fprintln(os.Stderr, "请使用且仅使用 -f、-v 或 -p 指定命名类型。")
 		os.Exit(1)
 	}
 
 	description := strings.TrimSpace(strings.Join(flag.Args(), " "))
+	if snippet != nil {
+		detail := snippet.Description()
+		if description != "" {
+			detail = fmt.Sprintf("%s\n\n补充说明：%s", detail, description)
+		}
+		description = detail
+	}
 	if description == "" {
 		fmt.Fprintln(os.Stderr, "请在参数中提供命名描述，例如：namesprout -f \"为一个Go库取函数名\"")
 		os.Exit(1)
 	}
 
-	configPath := resolveConfigPath(*cfgPath)
-
-	cfg, err := config.Load(configPath)
+	env, err := loadEnvironment(*cfgPath, *profile)
 	if err != nil {
-		fmt.Fprintf(os.Stderr, "加载配置失败：%v\n", err)
+		fmt.Fprintln(os.Stderr, err)
 		os.Exit(1)
 	}
 
-	promptPath := cfg.App.NamingPromptFile
-	if !filepath.IsAbs(promptPath) {
-		base := filepath.Dir(cfg.Source())
-		promptPath = filepath.Join(base, promptPath)
+	scanDir := *scanFlag
+	if scanDir == "" && snippet != nil {
+		scanDir = filepath.Dir(snippet.Path)
 	}
-
-	namingPrompts, err := prompts.LoadNamingPrompts(promptPath)
-	if err != nil {
-		fmt.Fprintf(os.Stderr, "加载命名提示配置失败：%v\n", err)
-		os.Exit(1)
-	}
-
-	appCtx, err := app.New(cfg)
-	if err != nil {
-		fmt.Fprintf(os.Stderr, "初始化应用失败：%v\n", err)
-		os.Exit(1)
-	}
-
-	providerName, providerSettings := cfg.DefaultProvider()
-	provider, err := appCtx.Provider(providerName)
-	if err != nil {
-		fmt.Fprintf(os.Stderr, "获取模型提供方失败：%v\n", err)
-		os.Exit(1)
+	var collisions *collision.Index
+	if scanDir != "" {
+		collisions, err = collision.ScanDir(scanDir)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "扫描已有标识符失败：%v\n", err)
+			os.Exit(1)
+		}
+		// 局部作用域需要语法树；文件存在语法错误时只提示，不影响代码上下文模式。
+		if snippet != nil && snippet.Parsed {
+			if err := collisions.AddGoScope(snippet.Path, snippet.Line); err != nil {
+				fmt.Fprintf(os.Stderr, "扫描局部标识符失败，仅检查包级标识符：%v\n", err)
+			}
+		}
 	}
 
 	var kind providers.NameKind
@@ -95,63 +147,52 @@ func main() {
 		kind = providers.NameKindVariable
 	case *projectFlag:
 		kind = providers.NameKindProject
+	case snippet != nil:
+		kind = snippet.Kind
 	default:
 		// 理论上不会触发，防御性处理。
 		kind = providers.NameKindFunction
 	}
 
-	kindDefinition, _ := namingPrompts.KindDefinition(kind)
-
-	var (
-		namingStyle providers.NamingStyle
-		definition  prompts.NamingPromptDefinition
-		ok          bool
-	)
+	reqOpts := app.RequestOptions{
+		Description: description,
+		Kind:        kind,
+		Style:       *caseFlag,
+	}
 
-	if rawStyle := strings.TrimSpace(*caseFlag); rawStyle != "" {
-		if namingStyle, definition, ok = namingPrompts.Lookup(rawStyle); !ok {
-			fmt.Fprintf(os.Stderr, "不支持的命名格式：%s\n", rawStyle)
-			os.Exit(1)
-		}
-	} else if kindDefinition.DefaultStyle != "" {
-		namingStyle = kindDefinition.DefaultStyle
-		if definition, ok = namingPrompts.Definition(namingStyle); !ok {
-			fmt.Fprintf(os.Stderr, "命名提示配置中缺少默认命名格式：%s\n", namingStyle)
+	if *exportFlag != "" {
+		if err := runExport(env, reqOpts, collisions, *dropFlag, *exportFlag, *exportFmt); err != nil {
+			fmt.Fprintf(os.Stderr, "导出失败：%v\n", err)
 			os.Exit(1)
 		}
-	} else {
-		namingStyle, err = providers.ParseNamingStyle(cfg.App.DefaultNamingStyle)
-		if err != nil {
-			fmt.Fprintf(os.Stderr, "配置中的默认命名格式无效：%v\n", err)
-			os.Exit(1)
-		}
-		definition, ok = namingPrompts.Definition(namingStyle)
-		if !ok {
-			fmt.Fprintf(os.Stderr, "命名提示配置中缺少默认命名格式：%s\n", namingStyle)
-			os.Exit(1)
-		}
-	}
-	if definition.Label == "" {
-		definition.Label = string(namingStyle)
+		return
 	}
 
-	kindLabel := kindDefinition.Label
-	if strings.TrimSpace(kindLabel) == "" {
-		kindLabel = string(kind)
+	providerName, providerSettings := env.cfg.DefaultProvider()
+	provider, err := env.app.Provider(providerName)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "获取模型提供方失败：%v\n", err)
+		os.Exit(1)
 	}
 
-	req := providers.Request{
-		Description:       description,
-		Kind:              kind,
-		Count:             cfg.App.MaxSuggestions,
-		KindLabel:         kindLabel,
-		KindPrompt:        kindDefinition.Prompt,
-		NamingStyle:       namingStyle,
-		NamingStyleLabel:  definition.Label,
-		NamingStylePrompt: definition.Prompt,
+	req, err := app.BuildRequest(env.cfg, env.prompts, env.glossary, reqOpts)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
 	}
 
-	model, err := ui.NewModel(providerName, provider, providerSettings, req)
+	// stdout 方式下复制的内容先缓存，待 TUI 退出后再输出，避免破坏界面渲染。
+	var copied bytes.Buffer
+	model, err := ui.NewModel(providerName, provider, providerSettings, req, ui.Options{
+		Glossary:       env.glossary,
+		RenameTarget:   snippet,
+		Collisions:     collisions,
+		DropCollisions: *dropFlag,
+		History:        env.history,
+		Clipboard:      clipboard.New(clipMode, &copied),
+		PrintOnSelect:  *printFlag,
+		ExportDir:      *exportDir,
+	})
 	if err != nil {
 		fmt.Fprintf(os.Stderr, "创建 UI 模型失败：%v\n", err)
 		os.Exit(1)
@@ -161,36 +202,24 @@ func main() {
 	if !*disableAlt {
 		options = append(options, tea.WithAltScreen())
 	}
+	if *printFlag {
+		// 标准输出留给选中的名称，界面渲染到标准错误，并按标准错误探测终端配色。
+		options = append(options, tea.WithOutput(os.Stderr))
+		stderr := lipgloss.NewRenderer(os.Stderr)
+		lipgloss.SetColorProfile(stderr.ColorProfile())
+		lipgloss.SetHasDarkBackground(stderr.HasDarkBackground())
+	}
 
 	if err := tea.NewProgram(model, options...).Start(); err != nil {
 		fmt.Fprintf(os.Stderr, "运行 TUI 失败：%v\n", err)
 		os.Exit(1)
 	}
-}
-
-func resolveConfigPath(path string) string {
-	if path == "" {
-		path = "config.yaml"
-	}
-
-	if filepath.IsAbs(path) {
-		return path
-	}
+	_, _ = copied.WriteTo(os.Stdout)
 
-	if _, err := os.Stat(path); err == nil {
-		return path
-	}
-
-	exePath, err := os.Executable()
-	if err != nil {
-		return path
-	}
-
-	exeDir := filepath.Dir(exePath)
-	candidate := filepath.Join(exeDir, path)
-	if _, err := os.Stat(candidate); err == nil {
-		return candidate
+	if *printFlag {
+		if model.Chosen() == "" {
+			os.Exit(1)
+		}
+		fmt.Println(model.Chosen())
 	}
-
-	return path
 }
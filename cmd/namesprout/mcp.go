@@ -0,0 +1,30 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/yanzzp/name-sprout/internal/mcp"
+)
+
+// runMCP 实现 `namesprout mcp`：通过 stdio 运行 MCP 服务器。
+func runMCP(args []string) int {
+	fs := flag.NewFlagSet("mcp", flag.ExitOnError)
+	cfgPath := fs.String("config", "", configFlagUsage)
+	profile := fs.String("profile", "", profileFlagUsage)
+	_ = fs.Parse(args)
+
+	env, err := loadEnvironment(*cfgPath, *profile)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 1
+	}
+
+	if err := mcp.NewServer(env.svc).Run(context.Background(), os.Stdin, os.Stdout); err != nil {
+		fmt.Fprintf(os.Stderr, "MCP 服务异常退出：%v\n", err)
+		return 1
+	}
+	return 0
+}
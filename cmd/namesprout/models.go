@@ -0,0 +1,99 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"path"
+	"time"
+
+	"github.com/yanzzp/name-sprout/internal/providers"
+)
+
+const listModelsTimeout = 30 * time.Second
+
+// runModels 实现 `namesprout models`：列出提供方当前凭据可用于生成名称的模型。
+func runModels(args []string) int {
+	fs := flag.NewFlagSet("models", flag.ExitOnError)
+	var (
+		cfgPath = fs.String("config", "", configFlagUsage)
+		profile = fs.String("profile", "", profileFlagUsage)
+		name    = fs.String("provider", "", "提供方名称，默认使用 app.default_provider")
+		asJSON  = fs.Bool("json", false, "以 JSON 输出模型列表")
+	)
+	_ = fs.Parse(args)
+
+	env, err := loadEnvironment(*cfgPath, *profile)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 1
+	}
+	if *name == "" {
+		*name = env.cfg.App.DefaultProvider
+	}
+
+	provider, err := env.app.Provider(*name)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 1
+	}
+	lister, ok := provider.(providers.ModelLister)
+	if !ok {
+		settings, _ := env.cfg.Provider(*name)
+		fmt.Fprintf(os.Stderr, "提供方 %s（%s）不支持列出模型。\n", *name, providers.DisplayName(settings.Type))
+		return 1
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), listModelsTimeout)
+	defer cancel()
+	models, err := lister.ListModels(ctx)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 1
+	}
+
+	if *asJSON {
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		if err := enc.Encode(models); err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			return 1
+		}
+		return 0
+	}
+
+	current := ""
+	if reporter, ok := provider.(providers.ModelReporter); ok {
+		current = reporter.ModelIdentifier()
+	}
+	found := false
+	for _, model := range models {
+		mark := " "
+		if sameModel(model.ID, current) {
+			mark, found = "*", true
+		}
+		line := fmt.Sprintf("%s %s", mark, model.ID)
+		if model.DisplayName != "" {
+			line += "  " + model.DisplayName
+		}
+		if model.InputTokenLimit > 0 {
+			line += fmt.Sprintf("  （输入 %d / 输出 %d tokens）", model.InputTokenLimit, model.OutputTokenLimit)
+		}
+		fmt.Println(line)
+	}
+	if len(models) == 0 {
+		fmt.Println("没有可用于生成内容的模型。")
+	}
+	if current != "" && !found {
+		fmt.Fprintf(os.Stderr, "\n当前配置的模型 %s 不在可用列表中，请在 providers.%s.model 中改为上述模型之一。\n", current, *name)
+		return 1
+	}
+	return 0
+}
+
+// sameModel 比较模型标识，忽略 models/、publishers/google/models/ 等资源前缀。
+func sameModel(a, b string) bool {
+	return path.Base(a) == path.Base(b)
+}
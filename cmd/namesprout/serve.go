@@ -0,0 +1,69 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/yanzzp/name-sprout/internal/server"
+)
+
+// runServe 实现 `namesprout serve`：在本地地址上提供 JSON API。
+func runServe(args []string) int {
+	fs := flag.NewFlagSet("serve", flag.ExitOnError)
+	var (
+		cfgPath       = fs.String("config", "", configFlagUsage)
+		profile       = fs.String("profile", "", profileFlagUsage)
+		addr          = fs.String("addr", "127.0.0.1:8787", "监听地址")
+		timeout       = fs.Duration("timeout", 60*time.Second, "单次生成的超时时间")
+		maxConcurrent = fs.Int("max-concurrent", 4, "同时进行的生成请求上限")
+	)
+	_ = fs.Parse(args)
+
+	env, err := loadEnvironment(*cfgPath, *profile)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 1
+	}
+
+	api := server.New(env.svc, server.Options{Timeout: *timeout, MaxConcurrent: *maxConcurrent})
+	httpServer := &http.Server{
+		Addr:              *addr,
+		Handler:           api,
+		ReadHeaderTimeout: 5 * time.Second,
+		ReadTimeout:       15 * time.Second,
+		WriteTimeout:      api.Timeout() + 10*time.Second,
+		IdleTimeout:       60 * time.Second,
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	errCh := make(chan error, 1)
+	go func() {
+		fmt.Fprintf(os.Stderr, "Name Sprout API 已启动：http://%s\n", *addr)
+		errCh <- httpServer.ListenAndServe()
+	}()
+
+	select {
+	case err := <-errCh:
+		if !errors.Is(err, http.ErrServerClosed) {
+			fmt.Fprintf(os.Stderr, "HTTP 服务异常退出：%v\n", err)
+			return 1
+		}
+	case <-ctx.Done():
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), api.Timeout())
+		defer cancel()
+		if err := httpServer.Shutdown(shutdownCtx); err != nil {
+			fmt.Fprintf(os.Stderr, "关闭 HTTP 服务失败：%v\n", err)
+			return 1
+		}
+	}
+	return 0
+}
@@ -14,6 +14,7 @@ type App struct {
 	cfg        *config.Config
 	mu         sync.Mutex
 	providers  map[string]providers.Provider
+	resolved   map[string]config.ProviderSettings
 	providerID []string
 }
 
@@ -30,6 +31,7 @@ func New(cfg *config.Config) (*App, error) {
 	return &App{
 		cfg:        cfg,
 		providers:  make(map[string]providers.Provider),
+		resolved:   make(map[string]config.ProviderSettings),
 		providerID: names,
 	}, nil
 }
@@ -58,16 +60,40 @@ func (a *App) Provider(name string) (providers.Provider, error) {
 		return p, nil
 	}
 
-	settings, ok := a.cfg.Provider(name)
-	if !ok {
-		return nil, fmt.Errorf("未知的 Provider: %s", name)
+	settings, err := a.resolve(name)
+	if err != nil {
+		return nil, err
 	}
 
 	instance, err := providers.New(name, settings)
 	if err != nil {
 		return nil, err
 	}
+	// 超出提供方上限的数量会被截断，直接报错以免 max_suggestions 被静默忽略。
+	if limiter, ok := instance.(providers.CountLimiter); ok && a.cfg.App.MaxSuggestions > limiter.MaxCount() {
+		return nil, fmt.Errorf("app.max_suggestions 为 %d，超过提供方 %s 单次最多生成的 %d 个", a.cfg.App.MaxSuggestions, name, limiter.MaxCount())
+	}
 
 	a.providers[name] = instance
 	return instance, nil
 }
+
+// ResolvedSettings 返回指定提供方展开密钥引用后实际使用的配置，同一提供方只解析一次。
+func (a *App) ResolvedSettings(name string) (config.ProviderSettings, error) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	return a.resolve(name)
+}
+
+// resolve 在首次使用时才解析密钥，单个提供方的环境变量缺失或命令失败不影响其它提供方。调用方需持有 a.mu。
+func (a *App) resolve(name string) (config.ProviderSettings, error) {
+	if settings, ok := a.resolved[name]; ok {
+		return settings, nil
+	}
+	settings, err := a.cfg.ResolveProvider(name)
+	if err != nil {
+		return config.ProviderSettings{}, err
+	}
+	a.resolved[name] = settings
+	return settings, nil
+}
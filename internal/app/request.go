@@ -0,0 +1,81 @@
+package app
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/yanzzp/name-sprout/internal/config"
+	"github.com/yanzzp/name-sprout/internal/glossary"
+	"github.com/yanzzp/name-sprout/internal/prompts"
+	"github.com/yanzzp/name-sprout/internal/providers"
+)
+
+// RequestOptions 描述构造命名请求所需的用户输入。
+type RequestOptions struct {
+	Description string
+	Kind        providers.NameKind
+	// Style 为用户输入的命名格式或其别名，为空时依次回退到配置方案、命名类型与全局默认格式。
+	Style string
+	// Count 为期望的候选数量，非正数时使用配置的 max_suggestions。
+	Count int
+}
+
+// BuildRequest 结合配置、命名提示与术语表构造发给 Provider 的请求。
+func BuildRequest(cfg *config.Config, lib *prompts.NamingPrompts, terms *glossary.Glossary, opts RequestOptions) (providers.Request, error) {
+	kindDefinition, _ := lib.KindDefinition(opts.Kind)
+
+	var (
+		namingStyle providers.NamingStyle
+		definition  prompts.NamingPromptDefinition
+		ok          bool
+		err         error
+	)
+
+	if rawStyle := strings.TrimSpace(opts.Style); rawStyle != "" {
+		if namingStyle, definition, ok = lib.Lookup(rawStyle); !ok {
+			return providers.Request{}, fmt.Errorf("不支持的命名格式：%s", rawStyle)
+		}
+	} else if rawStyle := cfg.KindStyle(string(opts.Kind)); rawStyle != "" {
+		if namingStyle, definition, ok = lib.Lookup(rawStyle); !ok {
+			return providers.Request{}, fmt.Errorf("配置方案 %q 中 %s 的命名格式无效：%s", cfg.Profile(), opts.Kind, rawStyle)
+		}
+	} else if kindDefinition.DefaultStyle != "" {
+		namingStyle = kindDefinition.DefaultStyle
+		if definition, ok = lib.Definition(namingStyle); !ok {
+			return providers.Request{}, fmt.Errorf("命名提示配置中缺少默认命名格式：%s", namingStyle)
+		}
+	} else {
+		namingStyle, err = providers.ParseNamingStyle(cfg.App.DefaultNamingStyle)
+		if err != nil {
+			return providers.Request{}, fmt.Errorf("配置中的默认命名格式无效：%w", err)
+		}
+		if definition, ok = lib.Definition(namingStyle); !ok {
+			return providers.Request{}, fmt.Errorf("命名提示配置中缺少默认命名格式：%s", namingStyle)
+		}
+	}
+	if definition.Label == "" {
+		definition.Label = string(namingStyle)
+	}
+
+	kindLabel := kindDefinition.Label
+	if strings.TrimSpace(kindLabel) == "" {
+		kindLabel = string(opts.Kind)
+	}
+
+	count := opts.Count
+	if count <= 0 {
+		count = cfg.App.MaxSuggestions
+	}
+
+	return providers.Request{
+		Description:       opts.Description,
+		Kind:              opts.Kind,
+		Count:             count,
+		KindLabel:         kindLabel,
+		KindPrompt:        kindDefinition.Prompt,
+		NamingStyle:       namingStyle,
+		NamingStyleLabel:  definition.Label,
+		NamingStylePrompt: definition.Prompt,
+		GlossaryPrompt:    terms.Prompt(),
+	}, nil
+}
@@ -0,0 +1,100 @@
+package app
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/yanzzp/name-sprout/internal/glossary"
+	"github.com/yanzzp/name-sprout/internal/history"
+	"github.com/yanzzp/name-sprout/internal/prompts"
+	"github.com/yanzzp/name-sprout/internal/providers"
+)
+
+// Service 聚合 App、命名提示与术语表，为 TUI 以外的入口（LSP、HTTP 等）提供统一的命名能力。
+type Service struct {
+	app      *App
+	prompts  *prompts.NamingPrompts
+	glossary *glossary.Glossary
+	history  *history.Store
+}
+
+// SuggestOptions 描述一次命名请求，Provider 为空时使用默认提供方。
+type SuggestOptions struct {
+	RequestOptions
+	Provider string
+	// Source 标记请求来源（如 lsp、serve），写入历史记录。
+	Source string
+}
+
+// Suggestion 为一次生成的结果。
+type Suggestion struct {
+	Provider string
+	Model    string
+	Request  providers.Request
+	Names    []string
+	// Dropped 为违反术语表而被剔除的名称。
+	Dropped []string
+}
+
+// NewService 构造命名服务，terms 与 store 可以为空。
+func NewService(a *App, lib *prompts.NamingPrompts, terms *glossary.Glossary, store *history.Store) (*Service, error) {
+	if a == nil || lib == nil {
+		return nil, fmt.Errorf("app 与命名提示配置不能为空")
+	}
+	return &Service{app: a, prompts: lib, glossary: terms, history: store}, nil
+}
+
+// App 返回底层应用上下文。
+func (s *Service) App() *App {
+	return s.app
+}
+
+// Prompts 返回命名提示库。
+func (s *Service) Prompts() *prompts.NamingPrompts {
+	return s.prompts
+}
+
+// Glossary 返回术语表，未配置时为 nil。
+func (s *Service) Glossary() *glossary.Glossary {
+	return s.glossary
+}
+
+// Suggest 构造请求、调用 Provider 并按术语表过滤结果。
+func (s *Service) Suggest(ctx context.Context, opts SuggestOptions) (*Suggestion, error) {
+	name := opts.Provider
+	if name == "" {
+		name = s.app.DefaultProviderName()
+	}
+	provider, err := s.app.Provider(name)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := BuildRequest(s.app.Config(), s.prompts, s.glossary, opts.RequestOptions)
+	if err != nil {
+		return nil, err
+	}
+
+	names, err := provider.GenerateNames(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &Suggestion{Provider: name, Request: req}
+	if reporter, ok := provider.(providers.ModelReporter); ok {
+		result.Model = reporter.ModelIdentifier()
+	}
+	result.Names, result.Dropped = s.glossary.Filter(names)
+
+	// 历史记录仅为辅助功能，写入失败不影响本次生成。
+	_, _ = s.history.Record(history.Entry{
+		Source:      opts.Source,
+		Description: req.Description,
+		Kind:        req.Kind,
+		Style:       req.NamingStyle,
+		Provider:    name,
+		Model:       result.Model,
+		Candidates:  result.Names,
+	})
+	return result, nil
+}
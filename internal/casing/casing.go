@@ -0,0 +1,111 @@
+package casing
+
+import (
+	"regexp"
+	"strings"
+	"unicode"
+
+	"github.com/yanzzp/name-sprout/internal/providers"
+)
+
+// Split 将标识符拆分为小写单词序列，兼容驼峰、蛇形、烤串以及连续大写缩写。
+func Split(name string) []string {
+	var (
+		words   []string
+		current []rune
+	)
+	flush := func() {
+		if len(current) > 0 {
+			words = append(words, strings.ToLower(string(current)))
+			current = current[:0]
+		}
+	}
+
+	runes := []rune(name)
+	for i, r := range runes {
+		switch {
+		case !unicode.IsLetter(r) && !unicode.IsDigit(r):
+			flush()
+			continue
+		case unicode.IsUpper(r) && len(current) > 0:
+			prev := runes[i-1]
+			nextLower := i+1 < len(runes) && unicode.IsLower(runes[i+1]) && !isPluralSuffix(runes[i+1:])
+			// fooBar -> foo|Bar；HTTPServer -> HTTP|Server；IDs 保持完整。
+			if unicode.IsLower(prev) || unicode.IsDigit(prev) || (unicode.IsUpper(prev) && nextLower) {
+				flush()
+			}
+		}
+		current = append(current, r)
+	}
+	flush()
+	return words
+}
+
+func isPluralSuffix(rest []rune) bool {
+	return rest[0] == 's' && (len(rest) == 1 || !unicode.IsLower(rest[1]))
+}
+
+// ContainsSequence 判断 words 中是否连续出现 target 序列。
+func ContainsSequence(words, target []string) bool {
+	if len(target) == 0 || len(target) > len(words) {
+		return false
+	}
+	for i := 0; i+len(target) <= len(words); i++ {
+		matched := true
+		for j, word := range target {
+			if words[i+j] != word {
+				matched = false
+				break
+			}
+		}
+		if matched {
+			return true
+		}
+	}
+	return false
+}
+
+var stylePatterns = map[providers.NamingStyle]*regexp.Regexp{
+	providers.NamingStyleLowerCamel: regexp.MustCompile(`^[a-z][a-zA-Z0-9]*$`),
+	providers.NamingStylePascal:     regexp.MustCompile(`^[A-Z][a-zA-Z0-9]*$`),
+	providers.NamingStyleSnake:      regexp.MustCompile(`^[a-z][a-z0-9]*(_[a-z0-9]+)*$`),
+	providers.NamingStyleKebab:      regexp.MustCompile(`^[a-z][a-z0-9]*(-[a-z0-9]+)*$`),
+}
+
+// Matches 判断名称是否符合指定命名格式。驼峰格式允许连续大写的缩写（如 parseJSON）。
+func Matches(name string, style providers.NamingStyle) bool {
+	pattern, ok := stylePatterns[style]
+	if !ok {
+		return false
+	}
+	return pattern.MatchString(name)
+}
+
+// Convert 将名称转换为指定命名格式，未知格式时原样返回。
+func Convert(name string, style providers.NamingStyle) string {
+	words := Split(name)
+	if len(words) == 0 {
+		return name
+	}
+	switch style {
+	case providers.NamingStyleLowerCamel:
+		return words[0] + joinTitle(words[1:])
+	case providers.NamingStylePascal:
+		return joinTitle(words)
+	case providers.NamingStyleSnake:
+		return strings.Join(words, "_")
+	case providers.NamingStyleKebab:
+		return strings.Join(words, "-")
+	}
+	return name
+}
+
+func joinTitle(words []string) string {
+	var b strings.Builder
+	for _, word := range words {
+		runes := []rune(word)
+		runes[0] = unicode.ToUpper(runes[0])
+		b.WriteString(string(runes))
+	}
+	return b.String()
+}
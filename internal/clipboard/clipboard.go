@@ -0,0 +1,112 @@
+package clipboard
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/atotto/clipboard"
+	"github.com/aymanbagabas/go-osc52/v2"
+)
+
+// Mode 表示写入剪贴板的方式。
+type Mode string
+
+const (
+	// ModeAuto 优先使用系统剪贴板，失败时回退到 OSC 52。
+	ModeAuto Mode = "auto"
+	// ModeSystem 只使用系统剪贴板（xclip、xsel、wl-copy、pbcopy 等）。
+	ModeSystem Mode = "system"
+	// ModeOSC52 通过终端转义序列写入剪贴板，适用于 SSH 与容器环境。
+	ModeOSC52 Mode = "osc52"
+	// ModeStdout 将文本写到标准输出，便于管道组合。
+	ModeStdout Mode = "stdout"
+)
+
+// Modes 列出支持的剪贴板方式，供命令行帮助与校验使用。
+var Modes = []Mode{ModeAuto, ModeSystem, ModeOSC52, ModeStdout}
+
+// ParseMode 将用户输入转换为剪贴板方式，空字符串视为 auto。
+func ParseMode(raw string) (Mode, error) {
+	raw = strings.ToLower(strings.TrimSpace(raw))
+	if raw == "" {
+		return ModeAuto, nil
+	}
+	for _, mode := range Modes {
+		if Mode(raw) == mode {
+			return mode, nil
+		}
+	}
+	return "", fmt.Errorf("未知的剪贴板方式 %q，可选值：%s", raw, joinModes())
+}
+
+func joinModes() string {
+	values := make([]string, len(Modes))
+	for i, mode := range Modes {
+		values[i] = string(mode)
+	}
+	return strings.Join(values, "、")
+}
+
+// Clipboard 按配置的方式写入文本。
+type Clipboard struct {
+	mode   Mode
+	stdout io.Writer
+}
+
+// New 创建剪贴板写入器，stdout 为 ModeStdout 时的输出目标，为空时使用 os.Stdout。
+func New(mode Mode, stdout io.Writer) *Clipboard {
+	if mode == "" {
+		mode = ModeAuto
+	}
+	if stdout == nil {
+		stdout = os.Stdout
+	}
+	return &Clipboard{mode: mode, stdout: stdout}
+}
+
+// Mode 返回配置的剪贴板方式。
+func (c *Clipboard) Mode() Mode {
+	return c.mode
+}
+
+// Write 写入文本并返回实际使用的方式。auto 模式下系统剪贴板不可用时回退到 OSC 52。
+func (c *Clipboard) Write(text string) (Mode, error) {
+	switch c.mode {
+	case ModeSystem:
+		return ModeSystem, clipboard.WriteAll(text)
+	case ModeOSC52:
+		return ModeOSC52, writeOSC52(text)
+	case ModeStdout:
+		_, err := fmt.Fprintln(c.stdout, text)
+		return ModeStdout, err
+	default:
+		if err := clipboard.WriteAll(text); err == nil {
+			return ModeSystem, nil
+		}
+		return ModeOSC52, writeOSC52(text)
+	}
+}
+
+// writeOSC52 将 OSC 52 序列写到控制终端。终端是否支持无法探测，写入成功不代表剪贴板已更新。
+func writeOSC52(text string) error {
+	seq := osc52.New(text)
+	switch {
+	case os.Getenv("TMUX") != "":
+		seq = seq.Tmux()
+	case strings.HasPrefix(os.Getenv("TERM"), "screen"):
+		seq = seq.Screen()
+	}
+
+	// 优先写入 /dev/tty，避免标准输出被重定向时序列混入输出内容。
+	var out io.Writer = os.Stderr
+	if tty, err := os.OpenFile("/dev/tty", os.O_WRONLY, 0); err == nil {
+		defer tty.Close()
+		out = tty
+	}
+	if _, err := seq.WriteTo(out); err != nil {
+		return fmt.Errorf("写入 OSC 52 序列失败: %w", err)
+	}
+	return nil
+}
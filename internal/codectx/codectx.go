@@ -0,0 +1,265 @@
+package codectx
+
+import (
+	"errors"
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/yanzzp/name-sprout/internal/providers"
+)
+
+const (
+	// textWindow 为非 Go 文件截取的上下文行数（目标行上下各取一半）。
+	textWindow = 30
+	// maxBodyLines 限制写入提示词的代码行数，避免超长函数撑爆上下文。
+	maxBodyLines = 120
+)
+
+// Snippet 描述从源码位置提取出的符号上下文。
+type Snippet struct {
+	Path     string
+	Language string
+	// Parsed 表示片段来自成功解析的 Go 语法树；Go 文件存在语法错误时退化为纯文本窗口，此时为 false。
+	Parsed    bool
+	Line      int
+	Column    int
+	Symbol    string
+	Kind      providers.NameKind
+	Doc       string
+	Signature string
+	Body      string
+}
+
+// Extract 读取文件并提取指定行附近的符号上下文，Go 文件基于语法树精确定位。
+func Extract(path string, line int) (*Snippet, error) {
+	src, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("读取源文件失败: %w", err)
+	}
+	return ExtractSource(path, src, line)
+}
+
+// ExtractSource 与 Extract 相同，但使用调用方提供的内容（例如编辑器中尚未保存的缓冲区）。
+func ExtractSource(path string, src []byte, line int) (*Snippet, error) {
+	if line <= 0 {
+		return nil, errors.New("行号必须大于 0")
+	}
+	if line > strings.Count(string(src), "\n")+1 {
+		return nil, fmt.Errorf("行号 %d 超出文件范围", line)
+	}
+
+	if strings.EqualFold(filepath.Ext(path), ".go") {
+		snippet, err := extractGo(path, src, line)
+		if err == nil {
+			return snippet, nil
+		}
+		// 语法错误时退化为纯文本窗口，保证仍可使用。
+	}
+	return extractText(path, src, line), nil
+}
+
+// Description 将上下文组织为可直接提交给模型的命名描述。
+func (s *Snippet) Description() string {
+	var b strings.Builder
+	target := "代码片段中的核心符号"
+	if s.Symbol != "" {
+		target = fmt.Sprintf("符号 `%s` ", s.Symbol)
+	}
+	b.WriteString(fmt.Sprintf("请根据以下 %s 源码为%s重新命名。\n", s.Language, target))
+	b.WriteString(fmt.Sprintf("位置：%s:%d\n", s.Path, s.Line))
+	if doc := strings.TrimSpace(s.Doc); doc != "" {
+		b.WriteString("\n文档注释：\n")
+		b.WriteString(doc)
+		b.WriteString("\n")
+	}
+	if sig := strings.TrimSpace(s.Signature); sig != "" {
+		b.WriteString("\n签名：\n")
+		b.WriteString(sig)
+		b.WriteString("\n")
+	}
+	if body := strings.TrimSpace(s.Body); body != "" {
+		b.WriteString("\n代码：\n")
+		b.WriteString(body)
+		b.WriteString("\n")
+	}
+	return strings.TrimSpace(b.String())
+}
+
+func extractGo(path string, src []byte, line int) (*Snippet, error) {
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, path, src, parser.ParseComments)
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		enclosing *ast.FuncDecl
+		ident     *ast.Ident
+		kind      providers.NameKind
+		decl      ast.Node
+		doc       *ast.CommentGroup
+	)
+
+	onLine := func(id *ast.Ident) bool {
+		return id != nil && id.Name != "_" && fset.Position(id.Pos()).Line == line
+	}
+	pick := func(id *ast.Ident, k providers.NameKind, node ast.Node, comments *ast.CommentGroup) {
+		if ident == nil {
+			ident, kind, decl, doc = id, k, node, comments
+		}
+	}
+
+	ast.Inspect(file, func(n ast.Node) bool {
+		if n == nil {
+			return false
+		}
+		start, end := fset.Position(n.Pos()).Line, fset.Position(n.End()).Line
+		if line < start || line > end {
+			return false
+		}
+		switch node := n.(type) {
+		case *ast.FuncDecl:
+			if enclosing == nil {
+				enclosing = node
+			}
+			if onLine(node.Name) {
+				pick(node.Name, providers.NameKindFunction, node, node.Doc)
+			}
+		case *ast.GenDecl:
+			for _, spec := range node.Specs {
+				vs, ok := spec.(*ast.ValueSpec)
+				if !ok {
+					continue
+				}
+				for _, name := range vs.Names {
+					if onLine(name) {
+						if len(node.Specs) == 1 {
+							comments := vs.Doc
+							if comments == nil {
+								comments = node.Doc
+							}
+							pick(name, providers.NameKindVariable, node, comments)
+						} else {
+							pick(name, providers.NameKindVariable, vs, vs.Doc)
+						}
+					}
+				}
+			}
+		case *ast.AssignStmt:
+			if node.Tok == token.DEFINE {
+				for _, expr := range node.Lhs {
+					if id, ok := expr.(*ast.Ident); ok && onLine(id) {
+						pick(id, providers.NameKindVariable, node, nil)
+					}
+				}
+			}
+		case *ast.RangeStmt:
+			if node.Tok == token.DEFINE {
+				for _, expr := range []ast.Expr{node.Key, node.Value} {
+					if id, ok := expr.(*ast.Ident); ok && onLine(id) {
+						pick(id, providers.NameKindVariable, node, nil)
+					}
+				}
+			}
+		}
+		return true
+	})
+
+	if ident == nil && enclosing == nil {
+		return nil, errors.New("指定行不在任何函数或变量声明内")
+	}
+	if ident == nil {
+		ident, kind, decl, doc = enclosing.Name, providers.NameKindFunction, enclosing, enclosing.Doc
+	}
+
+	pos := fset.Position(ident.Pos())
+	snippet := &Snippet{
+		Path:     path,
+		Language: "Go",
+		Parsed:   true,
+		Line:     pos.Line,
+		Column:   pos.Column,
+		Symbol:   ident.Name,
+		Kind:     kind,
+	}
+	if doc != nil {
+		snippet.Doc = doc.Text()
+	}
+
+	source := func(node ast.Node) string {
+		return string(src[fset.Position(node.Pos()).Offset:fset.Position(node.End()).Offset])
+	}
+	switch node := decl.(type) {
+	case *ast.FuncDecl:
+		if node.Body != nil {
+			snippet.Signature = string(src[fset.Position(node.Pos()).Offset:fset.Position(node.Body.Lbrace).Offset])
+		} else {
+			snippet.Signature = source(node)
+		}
+		snippet.Body = truncateLines(source(node))
+	default:
+		snippet.Signature = source(node)
+		if enclosing != nil {
+			snippet.Body = truncateLines(source(enclosing))
+		}
+	}
+	return snippet, nil
+}
+
+func extractText(path string, src []byte, line int) *Snippet {
+	lines := strings.Split(string(src), "\n")
+	start := line - 1 - textWindow/2
+	if start < 0 {
+		start = 0
+	}
+	end := start + textWindow
+	if end > len(lines) {
+		end = len(lines)
+	}
+	return &Snippet{
+		Path:     path,
+		Language: languageOf(path),
+		Line:     line,
+		Body:     strings.Join(lines[start:end], "\n"),
+	}
+}
+
+func truncateLines(text string) string {
+	lines := strings.Split(text, "\n")
+	if len(lines) <= maxBodyLines {
+		return text
+	}
+	return strings.Join(lines[:maxBodyLines], "\n") + "\n// ...（已截断）"
+}
+
+var languages = map[string]string{
+	".c":     "C",
+	".cc":    "C++",
+	".cpp":   "C++",
+	".cs":    "C#",
+	".go":    "Go",
+	".h":     "C",
+	".java":  "Java",
+	".js":    "JavaScript",
+	".jsx":   "JavaScript",
+	".kt":    "Kotlin",
+	".php":   "PHP",
+	".py":    "Python",
+	".rb":    "Ruby",
+	".rs":    "Rust",
+	".swift": "Swift",
+	".ts":    "TypeScript",
+	".tsx":   "TypeScript",
+}
+
+func languageOf(path string) string {
+	if lang, ok := languages[strings.ToLower(filepath.Ext(path))]; ok {
+		return lang
+	}
+	return "纯文本"
+}
@@ -0,0 +1,180 @@
+package collision
+
+import (
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+var identPattern = regexp.MustCompile(`[A-Za-z_$][A-Za-z0-9_$]*`)
+
+// sourceExts 为非 Go 文件启用分词扫描的扩展名。
+var sourceExts = map[string]bool{
+	".c": true, ".cc": true, ".cpp": true, ".cs": true, ".h": true, ".hpp": true,
+	".java": true, ".js": true, ".jsx": true, ".kt": true, ".php": true, ".py": true,
+	".rb": true, ".rs": true, ".swift": true, ".ts": true, ".tsx": true,
+}
+
+// Index 记录目录中已存在的标识符，用于识别与候选名称的冲突。
+type Index struct {
+	dir   string
+	names map[string]struct{}
+}
+
+// ScanDir 扫描目录（不递归）中的源码文件：Go 文件收集包级声明，其它语言按词法提取标识符。
+func ScanDir(dir string) (*Index, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, fmt.Errorf("读取目录失败: %w", err)
+	}
+
+	ix := &Index{dir: dir, names: make(map[string]struct{})}
+	fset := token.NewFileSet()
+	for _, entry := range entries {
+		if entry.IsDir() {
+			continue
+		}
+		path := filepath.Join(dir, entry.Name())
+		ext := strings.ToLower(filepath.Ext(entry.Name()))
+		switch {
+		case ext == ".go":
+			file, err := parser.ParseFile(fset, path, nil, parser.SkipObjectResolution)
+			if err != nil {
+				continue
+			}
+			ix.addGoDecls(file)
+		case sourceExts[ext]:
+			src, err := os.ReadFile(path)
+			if err != nil {
+				continue
+			}
+			for _, name := range identPattern.FindAllString(string(src), -1) {
+				ix.add(name)
+			}
+		}
+	}
+	return ix, nil
+}
+
+// AddGoScope 将 path 中包含 line 的函数内声明的局部标识符加入索引。
+func (ix *Index) AddGoScope(path string, line int) error {
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, path, nil, parser.SkipObjectResolution)
+	if err != nil {
+		return fmt.Errorf("解析源文件失败: %w", err)
+	}
+	for _, decl := range file.Decls {
+		fn, ok := decl.(*ast.FuncDecl)
+		if !ok || fset.Position(fn.Pos()).Line > line || fset.Position(fn.End()).Line < line {
+			continue
+		}
+		ast.Inspect(fn, func(n ast.Node) bool {
+			switch node := n.(type) {
+			case *ast.Field:
+				for _, name := range node.Names {
+					ix.add(name.Name)
+				}
+			case *ast.AssignStmt:
+				if node.Tok == token.DEFINE {
+					for _, expr := range node.Lhs {
+						if id, ok := expr.(*ast.Ident); ok {
+							ix.add(id.Name)
+						}
+					}
+				}
+			case *ast.ValueSpec:
+				for _, name := range node.Names {
+					ix.add(name.Name)
+				}
+			case *ast.RangeStmt:
+				for _, expr := range []ast.Expr{node.Key, node.Value} {
+					if id, ok := expr.(*ast.Ident); ok {
+						ix.add(id.Name)
+					}
+				}
+			}
+			return true
+		})
+	}
+	return nil
+}
+
+// Contains 判断名称是否已存在。nil 索引视为空。
+func (ix *Index) Contains(name string) bool {
+	if ix == nil {
+		return false
+	}
+	_, ok := ix.names[name]
+	return ok
+}
+
+// Dir 返回被扫描的目录。
+func (ix *Index) Dir() string {
+	if ix == nil {
+		return ""
+	}
+	return ix.dir
+}
+
+// Len 返回已收集的标识符数量。
+func (ix *Index) Len() int {
+	if ix == nil {
+		return 0
+	}
+	return len(ix.names)
+}
+
+// Partition 将名称划分为未冲突与已存在两组，保持原有顺序。
+func (ix *Index) Partition(names []string) (fresh, existing []string) {
+	if ix == nil {
+		return names, nil
+	}
+	fresh = make([]string, 0, len(names))
+	for _, name := range names {
+		if ix.Contains(name) {
+			existing = append(existing, name)
+			continue
+		}
+		fresh = append(fresh, name)
+	}
+	return fresh, existing
+}
+
+func (ix *Index) addGoDecls(file *ast.File) {
+	for _, decl := range file.Decls {
+		switch d := decl.(type) {
+		case *ast.FuncDecl:
+			// 方法位于接收者类型的作用域内，不与包级名称冲突。
+			if d.Recv == nil {
+				ix.add(d.Name.Name)
+			}
+		case *ast.GenDecl:
+			for _, spec := range d.Specs {
+				switch s := spec.(type) {
+				case *ast.ValueSpec:
+					for _, name := range s.Names {
+						ix.add(name.Name)
+					}
+				case *ast.TypeSpec:
+					ix.add(s.Name.Name)
+				case *ast.ImportSpec:
+					if s.Name != nil {
+						ix.add(s.Name.Name)
+					}
+				}
+			}
+		}
+	}
+}
+
+func (ix *Index) add(name string) {
+	if name == "" || name == "_" {
+		return
+	}
+	ix.names[name] = struct{}{}
+}
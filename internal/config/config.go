@@ -4,8 +4,11 @@ import (
 	"errors"
 	"fmt"
 	"os"
+	"path/filepath"
+	"sort"
+	"strings"
 
-	"gopkg.in/yaml.v3"
+	"github.com/yanzzp/name-sprout/internal/strictyaml"
 )
 
 // AppConfig 描述与界面和业务相关的基础配置。
@@ -13,12 +16,18 @@ type AppConfig struct {
 	DefaultProvider    string `yaml:"default_provider"`
 	MaxSuggestions     int    `yaml:"max_suggestions"`
 	DefaultNamingStyle string `yaml:"default_naming_style"`
-	NamingPromptFile   string `yaml:"naming_prompt_file"`
-	Proxy              string `yaml:"proxy"`
+	// NamingPromptFile 为空时只使用内置的命名提示，填写后在内置配置上覆盖或扩展。
+	NamingPromptFile string `yaml:"naming_prompt_file"`
+	GlossaryFile     string `yaml:"glossary_file"`
+	HistoryFile      string `yaml:"history_file"`
+	Proxy            string `yaml:"proxy"`
+	// Profile 为默认启用的配置方案，可被 --profile 覆盖，常用于仓库级配置。
+	Profile string `yaml:"profile"`
 }
 
 // ProviderSettings 抽象出不同模型提供方的通用配置字段。
-// Options 预留给未来扩展，例如自定义 base_url、组织ID等。
+// Options 承载特定提供方的扩展参数，例如 Gemini 的 backend、project、location；以 _file 结尾的值按相对配置文件目录的路径解析。
+// APIKey、Endpoint 与 Proxy 支持 ${ENV} 引用和 !cmd "..." 密钥命令，Load 时保留原始值，由 Config.ResolveProvider 按需解析。
 type ProviderSettings struct {
 	Type        string            `yaml:"type"`
 	APIKey      string            `yaml:"api_key"`
@@ -30,26 +39,50 @@ type ProviderSettings struct {
 	Proxy       string            `yaml:"proxy"`
 }
 
+// LintConfig 描述 lint 子命令的检查规则。
+// Styles 以标识符类别（function / variable / constant）为 key，未配置的类别回退到命名提示中的默认格式。
+type LintConfig struct {
+	Styles map[string]string `yaml:"styles"`
+}
+
 // Config 代表整份应用配置。
 type Config struct {
 	App       AppConfig                   `yaml:"app"`
 	Providers map[string]ProviderSettings `yaml:"providers"`
-	source    string
+	Lint      LintConfig                  `yaml:"lint"`
+	Profiles  map[string]Profile          `yaml:"profiles"`
+
+	source     string
+	sources    []string
+	profile    string
+	kindStyles map[string]string
 }
 
 // Load 从指定路径读取并解析配置文件。
 func Load(path string) (*Config, error) {
-	raw, err := os.ReadFile(path)
-	if err != nil {
-		return nil, fmt.Errorf("读取配置文件失败: %w", err)
+	return LoadLayers(Layer{Path: path})
+}
+
+// LoadLayers 按优先级从高到低读取多份配置并合并：高优先级文件中出现的字段覆盖低优先级的同名字段，
+// 提供方按名称逐字段合并。各文件中的相对路径以该文件所在目录为基准解析。
+func LoadLayers(layers ...Layer) (*Config, error) {
+	if len(layers) == 0 {
+		return nil, errors.New("未指定配置文件")
 	}
 
 	var cfg Config
-	if err := yaml.Unmarshal(raw, &cfg); err != nil {
-		return nil, fmt.Errorf("解析配置文件失败: %w", err)
+	for i := len(layers) - 1; i >= 0; i-- {
+		layer, err := loadLayer(layers[i])
+		if err != nil {
+			return nil, err
+		}
+		cfg.overlay(layer)
 	}
 
-	cfg.source = path
+	for _, layer := range layers {
+		cfg.sources = append(cfg.sources, layer.Path)
+	}
+	cfg.source = layers[len(layers)-1].Path
 	cfg.setDefaults()
 
 	if err := cfg.Validate(); err != nil {
@@ -59,17 +92,65 @@ func Load(path string) (*Config, error) {
 	return &cfg, nil
 }
 
+// loadLayer 解析单个配置文件，保留密钥引用的原始写法并将其中的相对路径转换为绝对路径。
+func loadLayer(layer Layer) (*Config, error) {
+	path := layer.Path
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("读取配置文件失败: %w", err)
+	}
+
+	var cfg Config
+	if err := strictyaml.Unmarshal(raw, &cfg); err != nil {
+		return nil, fmt.Errorf("解析配置文件 %s 失败: %w", path, err)
+	}
+	if layer.Repo {
+		if err := checkRepoLayer(raw); err != nil {
+			return nil, fmt.Errorf("%s: %w", path, err)
+		}
+	}
+	commands, err := commandTags(raw)
+	if err != nil {
+		return nil, fmt.Errorf("解析配置文件 %s 失败: %w", path, err)
+	}
+	if err := cfg.keepCommands(commands); err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+
+	dir := filepath.Dir(path)
+	if abs, err := filepath.Abs(dir); err == nil {
+		dir = abs
+	}
+	for _, field := range []*string{&cfg.App.NamingPromptFile, &cfg.App.GlossaryFile, &cfg.App.HistoryFile} {
+		if *field != "" && !filepath.IsAbs(*field) {
+			*field = filepath.Join(dir, *field)
+		}
+	}
+	// options 中以 _file 结尾的值视为文件路径（如 Vertex AI 的 credentials_file）。
+	for _, settings := range cfg.Providers {
+		for key, value := range settings.Options {
+			if strings.HasSuffix(key, "_file") && value != "" && !filepath.IsAbs(value) {
+				settings.Options[key] = filepath.Join(dir, value)
+			}
+		}
+	}
+	for name, profile := range cfg.Profiles {
+		if profile.PromptFile != "" && !filepath.IsAbs(profile.PromptFile) {
+			profile.PromptFile = filepath.Join(dir, profile.PromptFile)
+			cfg.Profiles[name] = profile
+		}
+	}
+	return &cfg, nil
+}
+
 // setDefaults 填充缺省值，避免在业务逻辑中散落常量。
 func (c *Config) setDefaults() {
-	if c.App.MaxSuggestions <= 0 {
+	if c.App.MaxSuggestions == 0 {
 		c.App.MaxSuggestions = 5
 	}
 	if c.App.DefaultNamingStyle == "" {
 		c.App.DefaultNamingStyle = "lower_camel"
 	}
-	if c.App.NamingPromptFile == "" {
-		c.App.NamingPromptFile = "prompts/naming.yaml"
-	}
 	if c.Providers == nil {
 		c.Providers = make(map[string]ProviderSettings)
 	}
@@ -93,24 +174,83 @@ func (c *Config) setDefaults() {
 	}
 }
 
-// Validate 校验关键字段是否就绪。
+// Validate 校验默认提供方、全部提供方与配置方案的字段及取值范围，一次返回所有问题。
 func (c *Config) Validate() error {
+	var errs []error
 	if c.App.DefaultProvider == "" {
-		return errors.New("配置缺少 app.default_provider 字段")
+		errs = append(errs, errors.New("配置缺少 app.default_provider 字段"))
+	} else if _, ok := c.Providers[c.App.DefaultProvider]; !ok {
+		errs = append(errs, fmt.Errorf("未找到默认提供方 %q 的配置", c.App.DefaultProvider))
+	}
+	if err := checkCount("app.max_suggestions", c.App.MaxSuggestions); err != nil {
+		errs = append(errs, err)
 	}
 
-	settings, ok := c.Providers[c.App.DefaultProvider]
-	if !ok {
-		return fmt.Errorf("未找到默认提供方 %q 的配置", c.App.DefaultProvider)
+	for _, name := range sortedKeys(c.Providers) {
+		settings := c.Providers[name]
+		if settings.Type == "" {
+			errs = append(errs, fmt.Errorf("提供方 %q 缺少 type 字段", name))
+		}
+		if err := checkTemperature("providers."+name+".temperature", settings.Temperature); err != nil {
+			errs = append(errs, err)
+		}
+		if settings.TopK != nil && *settings.TopK <= 0 {
+			errs = append(errs, fmt.Errorf("providers.%s.top_k 必须大于 0，当前为 %g", name, *settings.TopK))
+		}
 	}
 
-	if settings.Type == "" {
-		return fmt.Errorf("提供方 %q 缺少 type 字段", c.App.DefaultProvider)
+	for _, name := range sortedKeys(c.Profiles) {
+		profile := c.Profiles[name]
+		if profile.Provider != "" {
+			if _, ok := c.Providers[profile.Provider]; !ok {
+				errs = append(errs, fmt.Errorf("配置方案 %q 引用了未配置的提供方 %q", name, profile.Provider))
+			}
+		}
+		if err := checkTemperature("profiles."+name+".temperature", profile.Temperature); err != nil {
+			errs = append(errs, err)
+		}
+		if profile.Count != 0 {
+			if err := checkCount("profiles."+name+".count", profile.Count); err != nil {
+				errs = append(errs, err)
+			}
+		}
 	}
 
+	if c.App.Profile != "" {
+		if _, ok := c.Profiles[c.App.Profile]; !ok {
+			errs = append(errs, fmt.Errorf("app.profile 引用了不存在的配置方案 %q", c.App.Profile))
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// MaxSuggestionsLimit 为单次生成数量的上限，过大的值会显著拖慢响应并超出模型输出长度。
+const MaxSuggestionsLimit = 50
+
+func checkCount(field string, count int) error {
+	if count < 1 || count > MaxSuggestionsLimit {
+		return fmt.Errorf("%s 必须在 1 到 %d 之间，当前为 %d", field, MaxSuggestionsLimit, count)
+	}
+	return nil
+}
+
+func checkTemperature(field string, temperature *float32) error {
+	if temperature != nil && (*temperature < 0 || *temperature > 2) {
+		return fmt.Errorf("%s 必须在 0 到 2 之间，当前为 %g", field, *temperature)
+	}
 	return nil
 }
 
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
 // DefaultProvider 返回默认提供方的配置。
 func (c *Config) DefaultProvider() (string, ProviderSettings) {
 	return c.App.DefaultProvider, c.Providers[c.App.DefaultProvider]
@@ -122,7 +262,12 @@ func (c *Config) Provider(name string) (ProviderSettings, bool) {
 	return settings, ok
 }
 
-// Source 返回配置文件来源，方便调试信息展示。
+// Source 返回配置文件来源。多层配置时为优先级最低的基础配置。
 func (c *Config) Source() string {
 	return c.source
 }
+
+// Sources 按优先级从高到低返回参与合并的全部配置文件，方便调试信息展示。
+func (c *Config) Sources() []string {
+	return c.sources
+}
@@ -0,0 +1,214 @@
+package config
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"regexp"
+	"runtime"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// secretCommandTimeout 限制 !cmd 密钥命令的执行时间，避免卡住启动流程。
+const secretCommandTimeout = 10 * time.Second
+
+// commandTag 为 YAML 中声明密钥命令的标签，如 api_key: !cmd "pass show gemini"。
+const commandTag = "!cmd"
+
+var envPattern = regexp.MustCompile(`\$\{([A-Za-z_][A-Za-z0-9_]*)\}`)
+
+// SecretError 表示提供方的 ${ENV} 引用或 !cmd 密钥命令解析失败，Field 为点分字段路径。
+type SecretError struct {
+	Field string
+	Err   error
+}
+
+func (e *SecretError) Error() string {
+	return fmt.Sprintf("解析 %s 失败: %v", e.Field, e.Err)
+}
+
+func (e *SecretError) Unwrap() error {
+	return e.Err
+}
+
+// keepCommands 将以 !cmd 标签书写的字段改写为字符串形式的 "!cmd ..."，留待 ResolveProvider 按需执行。
+// commands 为点分路径 → 命令，只有 app.proxy 及各提供方的 api_key、proxy、endpoint 可以使用密钥命令。
+func (c *Config) keepCommands(commands map[string]string) error {
+	keep := func(path string, value *string) {
+		if command, ok := commands[path]; ok {
+			delete(commands, path)
+			*value = commandTag + " " + strconv.Quote(command)
+		}
+	}
+
+	keep("app.proxy", &c.App.Proxy)
+	for name, settings := range c.Providers {
+		prefix := "providers." + name + "."
+		keep(prefix+"api_key", &settings.APIKey)
+		keep(prefix+"proxy", &settings.Proxy)
+		keep(prefix+"endpoint", &settings.Endpoint)
+		c.Providers[name] = settings
+	}
+
+	if len(commands) > 0 {
+		paths := make([]string, 0, len(commands))
+		for path := range commands {
+			paths = append(paths, path)
+		}
+		sort.Strings(paths)
+		return fmt.Errorf("%s 不支持 !cmd，仅 api_key、proxy、endpoint 可使用密钥命令", strings.Join(paths, "、"))
+	}
+	return nil
+}
+
+// ResolveProvider 返回指定提供方展开了 api_key、proxy、endpoint 中 ${ENV} 引用与 !cmd 密钥命令的配置。
+// 加载配置时保留原始值，只在实际创建提供方时调用，未使用的提供方不会读取环境变量或执行命令。
+func (c *Config) ResolveProvider(name string) (ProviderSettings, error) {
+	settings, ok := c.Providers[name]
+	if !ok {
+		return ProviderSettings{}, fmt.Errorf("未知的 Provider: %s", name)
+	}
+
+	fields := []struct {
+		key   string
+		value *string
+	}{
+		{"api_key", &settings.APIKey},
+		{"proxy", &settings.Proxy},
+		{"endpoint", &settings.Endpoint},
+	}
+	for _, field := range fields {
+		expanded, err := ExpandValue(*field.value)
+		if err != nil {
+			return ProviderSettings{}, &SecretError{Field: "providers." + name + "." + field.key, Err: err}
+		}
+		*field.value = expanded
+	}
+	return settings, nil
+}
+
+// ExpandValue 展开单个配置值：以 "!cmd " 开头时执行命令取其输出，否则替换其中的 ${ENV} 引用。
+// ResolveProvider 会对密钥相关字段调用它，尚未写入配置文件的值（如 init 向导中的输入）也可直接使用。
+func ExpandValue(raw string) (string, error) {
+	if command, ok := strings.CutPrefix(strings.TrimSpace(raw), commandTag+" "); ok {
+		return runSecretCommand(unquote(command))
+	}
+
+	var missing []string
+	expanded := envPattern.ReplaceAllStringFunc(raw, func(match string) string {
+		name := envPattern.FindStringSubmatch(match)[1]
+		value, ok := os.LookupEnv(name)
+		if !ok {
+			missing = append(missing, name)
+		}
+		return value
+	})
+	if len(missing) > 0 {
+		return "", fmt.Errorf("环境变量 %s 未设置", strings.Join(missing, "、"))
+	}
+	return expanded, nil
+}
+
+// runSecretCommand 通过系统 shell 执行密钥命令，取标准输出的第一行作为结果（与 pass 等工具的约定一致）。
+func runSecretCommand(command string) (string, error) {
+	command = strings.TrimSpace(command)
+	if command == "" {
+		return "", errors.New("!cmd 命令为空")
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), secretCommandTimeout)
+	defer cancel()
+
+	var cmd *exec.Cmd
+	if runtime.GOOS == "windows" {
+		cmd = exec.CommandContext(ctx, "cmd", "/C", command)
+	} else {
+		cmd = exec.CommandContext(ctx, "sh", "-c", command)
+	}
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+
+	out, err := cmd.Output()
+	if err != nil {
+		if detail := strings.TrimSpace(stderr.String()); detail != "" {
+			return "", fmt.Errorf("执行命令 %q 失败: %w: %s", command, err, detail)
+		}
+		return "", fmt.Errorf("执行命令 %q 失败: %w", command, err)
+	}
+	value, _, _ := strings.Cut(string(out), "\n")
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return "", fmt.Errorf("命令 %q 没有输出", command)
+	}
+	return value, nil
+}
+
+// commandTags 找出 YAML 中以 !cmd 标签书写的标量，返回点分路径到命令的映射。
+// yaml.v3 解码到字符串时会丢弃未知标签，因此需要单独遍历节点树。
+func commandTags(raw []byte) (map[string]string, error) {
+	commands := make(map[string]string)
+	err := walkScalars(raw, func(path string, node *yaml.Node) {
+		if node.Tag == commandTag {
+			commands[path] = node.Value
+		}
+	})
+	if err != nil {
+		return nil, err
+	}
+	return commands, nil
+}
+
+// walkScalars 遍历 YAML 中映射下的全部标量，以点分路径回调。
+func walkScalars(raw []byte, fn func(path string, node *yaml.Node)) error {
+	var root yaml.Node
+	if err := yaml.Unmarshal(raw, &root); err != nil {
+		return err
+	}
+
+	var walk func(node *yaml.Node, path string)
+	walk = func(node *yaml.Node, path string) {
+		switch node.Kind {
+		case yaml.DocumentNode, yaml.SequenceNode:
+			for _, child := range node.Content {
+				walk(child, path)
+			}
+		case yaml.MappingNode:
+			for i := 0; i+1 < len(node.Content); i += 2 {
+				key := node.Content[i].Value
+				if path != "" {
+					key = path + "." + key
+				}
+				walk(node.Content[i+1], key)
+			}
+		case yaml.ScalarNode:
+			fn(path, node)
+		case yaml.AliasNode:
+			if node.Alias != nil {
+				walk(node.Alias, path)
+			}
+		}
+	}
+	walk(&root, "")
+	return nil
+}
+
+func unquote(value string) string {
+	value = strings.TrimSpace(value)
+	if strings.HasPrefix(value, `"`) {
+		if unquoted, err := strconv.Unquote(value); err == nil {
+			return unquoted
+		}
+	}
+	if len(value) >= 2 && strings.HasPrefix(value, "'") && strings.HasSuffix(value, "'") {
+		return value[1 : len(value)-1]
+	}
+	return value
+}
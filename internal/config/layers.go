@@ -0,0 +1,217 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	// EnvConfig 为显式指定配置文件的环境变量，优先级最高。
+	EnvConfig = "NAMESPROUT_CONFIG"
+	// RepoConfigName 为仓库级配置文件名，从当前目录向上查找直到仓库根目录。
+	RepoConfigName = ".namesprout.yaml"
+)
+
+// Layer 为参与合并的一份配置文件。
+type Layer struct {
+	Path string
+	// Repo 标记从工作目录向上发现的仓库级配置。仓库内容不一定可信，
+	// 因此这类文件只能覆盖命名风格、类别与配置方案等约定，不能设置提供方、代理或执行密钥命令。
+	Repo bool
+}
+
+// repoLayerFields 为仓库级配置允许出现的字段，按点分路径前缀匹配。
+var repoLayerFields = []string{
+	"app.default_provider",
+	"app.max_suggestions",
+	"app.default_naming_style",
+	"app.naming_prompt_file",
+	"app.glossary_file",
+	"app.profile",
+	"lint",
+	"profiles",
+}
+
+// UserConfigPath 返回遵循 XDG 规范的用户级配置文件路径。
+func UserConfigPath() (string, error) {
+	dir := os.Getenv("XDG_CONFIG_HOME")
+	if dir == "" {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return "", fmt.Errorf("无法确定用户目录: %w", err)
+		}
+		dir = filepath.Join(home, ".config")
+	}
+	return filepath.Join(dir, "namesprout", "config.yaml"), nil
+}
+
+// Discover 按优先级从高到低返回存在的配置文件：
+//  1. $NAMESPROUT_CONFIG；
+//  2. 当前目录及其上级目录中的 .namesprout.yaml，遇到 .git 所在的仓库根目录为止，越近优先级越高；
+//  3. $XDG_CONFIG_HOME/namesprout/config.yaml（默认 ~/.config/namesprout/config.yaml）；
+//  4. 可执行文件所在目录下的 config.yaml。
+//
+// 当前目录下的 config.yaml 不会被自动读取，以免其它项目的同名文件混入配置；需要时请显式传入 --config。
+// 第 2 步找到的文件标记为仓库级配置，加载时按 Layer.Repo 的约束检查。
+func Discover() ([]Layer, error) {
+	var (
+		layers []Layer
+		seen   = make(map[string]bool)
+	)
+	add := func(path string, repo bool) bool {
+		if abs, err := filepath.Abs(path); err == nil {
+			path = abs
+		}
+		if seen[path] {
+			return true
+		}
+		info, err := os.Stat(path)
+		if err != nil || info.IsDir() {
+			return false
+		}
+		seen[path] = true
+		layers = append(layers, Layer{Path: path, Repo: repo})
+		return true
+	}
+
+	if path := os.Getenv(EnvConfig); path != "" {
+		if !add(path, false) {
+			return nil, fmt.Errorf("环境变量 %s 指向的配置文件 %s 不存在", EnvConfig, path)
+		}
+	}
+
+	if cwd, err := os.Getwd(); err == nil {
+		for dir := cwd; ; {
+			add(filepath.Join(dir, RepoConfigName), true)
+			if _, err := os.Stat(filepath.Join(dir, ".git")); err == nil {
+				break
+			}
+			parent := filepath.Dir(dir)
+			if parent == dir {
+				break
+			}
+			dir = parent
+		}
+	}
+
+	if path, err := UserConfigPath(); err == nil {
+		add(path, false)
+	}
+
+	if exe, err := os.Executable(); err == nil {
+		add(filepath.Join(filepath.Dir(exe), "config.yaml"), false)
+	}
+
+	if len(layers) == 0 {
+		return nil, errors.New("未找到配置文件，可通过 --config、$NAMESPROUT_CONFIG 或 ~/.config/namesprout/config.yaml 提供")
+	}
+	return layers, nil
+}
+
+// checkRepoLayer 检查仓库级配置：拒绝允许范围以外的字段，以及 !cmd 密钥命令与 ${ENV} 引用，
+// 避免克隆来的仓库借配置执行命令，或把用户的 API Key 发往仓库指定的 endpoint、proxy。
+func checkRepoLayer(raw []byte) error {
+	var fields, secrets []string
+	err := walkScalars(raw, func(path string, node *yaml.Node) {
+		if !repoLayerField(path) {
+			fields = append(fields, path)
+		}
+		if node.Tag == commandTag || strings.HasPrefix(strings.TrimSpace(node.Value), commandTag+" ") || envPattern.MatchString(node.Value) {
+			secrets = append(secrets, path)
+		}
+	})
+	if err != nil {
+		return err
+	}
+
+	var errs []error
+	if len(fields) > 0 {
+		sort.Strings(fields)
+		errs = append(errs, fmt.Errorf("仓库级配置不能设置 %s，提供方、凭据与代理只能写在用户配置中", strings.Join(fields, "、")))
+	}
+	if len(secrets) > 0 {
+		sort.Strings(secrets)
+		errs = append(errs, fmt.Errorf("仓库级配置不能使用 !cmd 或 ${ENV}：%s", strings.Join(secrets, "、")))
+	}
+	return errors.Join(errs...)
+}
+
+func repoLayerField(path string) bool {
+	for _, field := range repoLayerFields {
+		if path == field || strings.HasPrefix(path, field+".") {
+			return true
+		}
+	}
+	return false
+}
+
+// overlay 将高优先级配置 top 中出现的字段覆盖到 c 上。
+func (c *Config) overlay(top *Config) {
+	override(&c.App.DefaultProvider, top.App.DefaultProvider)
+	override(&c.App.DefaultNamingStyle, top.App.DefaultNamingStyle)
+	override(&c.App.NamingPromptFile, top.App.NamingPromptFile)
+	override(&c.App.GlossaryFile, top.App.GlossaryFile)
+	override(&c.App.HistoryFile, top.App.HistoryFile)
+	override(&c.App.Proxy, top.App.Proxy)
+	override(&c.App.Profile, top.App.Profile)
+	if top.App.MaxSuggestions != 0 {
+		c.App.MaxSuggestions = top.App.MaxSuggestions
+	}
+
+	if c.Providers == nil && len(top.Providers) > 0 {
+		c.Providers = make(map[string]ProviderSettings, len(top.Providers))
+	}
+	for name, settings := range top.Providers {
+		base := c.Providers[name]
+		base.overlay(settings)
+		c.Providers[name] = base
+	}
+
+	if c.Profiles == nil && len(top.Profiles) > 0 {
+		c.Profiles = make(map[string]Profile, len(top.Profiles))
+	}
+	for name, profile := range top.Profiles {
+		base := c.Profiles[name]
+		base.overlay(profile)
+		c.Profiles[name] = base
+	}
+
+	if c.Lint.Styles == nil && len(top.Lint.Styles) > 0 {
+		c.Lint.Styles = make(map[string]string, len(top.Lint.Styles))
+	}
+	for kind, style := range top.Lint.Styles {
+		c.Lint.Styles[kind] = style
+	}
+}
+
+func (s *ProviderSettings) overlay(top ProviderSettings) {
+	override(&s.Type, top.Type)
+	override(&s.APIKey, top.APIKey)
+	override(&s.Model, top.Model)
+	override(&s.Endpoint, top.Endpoint)
+	override(&s.Proxy, top.Proxy)
+	if top.Temperature != nil {
+		s.Temperature = top.Temperature
+	}
+	if top.TopK != nil {
+		s.TopK = top.TopK
+	}
+	if s.Options == nil && len(top.Options) > 0 {
+		s.Options = make(map[string]string, len(top.Options))
+	}
+	for key, value := range top.Options {
+		s.Options[key] = value
+	}
+}
+
+func override(dst *string, value string) {
+	if value != "" {
+		*dst = value
+	}
+}
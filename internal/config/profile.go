@@ -0,0 +1,95 @@
+package config
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// Profile 将一组常用默认值打包成命名方案，通过 --profile 或 app.profile 选择。
+// 未填写的字段沿用配置中的原有值。
+type Profile struct {
+	Provider    string   `yaml:"provider"`
+	Model       string   `yaml:"model"`
+	Temperature *float32 `yaml:"temperature"`
+	// Styles 以命名类型（function / variable / project）为 key 指定默认命名格式，支持别名。
+	Styles     map[string]string `yaml:"styles"`
+	Count      int               `yaml:"count"`
+	PromptFile string            `yaml:"prompt_file"`
+}
+
+// ApplyProfile 将指定方案覆盖到配置上。name 为空时使用 app.profile，两者都为空时不做任何修改。
+func (c *Config) ApplyProfile(name string) error {
+	if name == "" {
+		name = c.App.Profile
+	}
+	if name == "" {
+		return nil
+	}
+
+	profile, ok := c.Profiles[name]
+	if !ok {
+		return fmt.Errorf("未找到配置方案 %q，可选：%s", name, strings.Join(c.ProfileNames(), "、"))
+	}
+
+	if profile.Provider != "" {
+		if _, ok := c.Providers[profile.Provider]; !ok {
+			return fmt.Errorf("配置方案 %q 引用了未配置的提供方 %q", name, profile.Provider)
+		}
+		c.App.DefaultProvider = profile.Provider
+	}
+	if profile.Model != "" || profile.Temperature != nil {
+		settings := c.Providers[c.App.DefaultProvider]
+		override(&settings.Model, profile.Model)
+		if profile.Temperature != nil {
+			settings.Temperature = profile.Temperature
+		}
+		c.Providers[c.App.DefaultProvider] = settings
+	}
+	if profile.Count > 0 {
+		c.App.MaxSuggestions = profile.Count
+	}
+	override(&c.App.NamingPromptFile, profile.PromptFile)
+
+	c.profile = name
+	c.kindStyles = profile.Styles
+	return nil
+}
+
+// Profile 返回当前生效的配置方案名称，未使用方案时为空。
+func (c *Config) Profile() string {
+	return c.profile
+}
+
+// ProfileNames 返回全部配置方案名称，按字母排序。
+func (c *Config) ProfileNames() []string {
+	names := make([]string, 0, len(c.Profiles))
+	for name := range c.Profiles {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// KindStyle 返回当前方案为指定命名类型设置的默认命名格式，未设置时为空。
+func (c *Config) KindStyle(kind string) string {
+	return c.kindStyles[kind]
+}
+
+func (p *Profile) overlay(top Profile) {
+	override(&p.Provider, top.Provider)
+	override(&p.Model, top.Model)
+	override(&p.PromptFile, top.PromptFile)
+	if top.Temperature != nil {
+		p.Temperature = top.Temperature
+	}
+	if top.Count != 0 {
+		p.Count = top.Count
+	}
+	if p.Styles == nil && len(top.Styles) > 0 {
+		p.Styles = make(map[string]string, len(top.Styles))
+	}
+	for kind, style := range top.Styles {
+		p.Styles[kind] = style
+	}
+}
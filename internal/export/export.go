@@ -0,0 +1,155 @@
+package export
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/yanzzp/name-sprout/internal/providers"
+)
+
+// 支持的导出格式。
+const (
+	FormatJSON     = "json"
+	FormatMarkdown = "markdown"
+	FormatCSV      = "csv"
+)
+
+// Document 汇总一次生成的候选与请求元数据。
+type Document struct {
+	GeneratedAt time.Time             `json:"generated_at"`
+	Provider    string                `json:"provider"`
+	Model       string                `json:"model,omitempty"`
+	Kind        providers.NameKind    `json:"kind"`
+	KindLabel   string                `json:"kind_label,omitempty"`
+	Style       providers.NamingStyle `json:"style,omitempty"`
+	StyleLabel  string                `json:"style_label,omitempty"`
+	Description string                `json:"description"`
+	Candidates  []string              `json:"candidates"`
+}
+
+// NewDocument 基于请求与生成结果构造导出文档。
+func NewDocument(req providers.Request, provider, model string, names []string) Document {
+	candidates := append([]string{}, names...)
+	return Document{
+		GeneratedAt: time.Now(),
+		Provider:    provider,
+		Model:       model,
+		Kind:        req.Kind,
+		KindLabel:   req.KindLabel,
+		Style:       req.NamingStyle,
+		StyleLabel:  req.NamingStyleLabel,
+		Description: req.Description,
+		Candidates:  candidates,
+	}
+}
+
+// ParseFormat 解析格式名称，兼容常见的扩展名写法。
+func ParseFormat(raw string) (string, error) {
+	switch strings.ToLower(strings.TrimPrefix(strings.TrimSpace(raw), ".")) {
+	case "json":
+		return FormatJSON, nil
+	case "md", "markdown":
+		return FormatMarkdown, nil
+	case "csv":
+		return FormatCSV, nil
+	}
+	return "", fmt.Errorf("不支持的导出格式: %s", raw)
+}
+
+// FormatFromPath 根据文件扩展名推断导出格式。
+func FormatFromPath(path string) (string, error) {
+	ext := filepath.Ext(path)
+	if ext == "" {
+		return "", fmt.Errorf("无法根据 %q 推断导出格式，请显式指定", path)
+	}
+	return ParseFormat(ext)
+}
+
+// Extension 返回格式对应的文件扩展名。
+func Extension(format string) string {
+	if format == FormatMarkdown {
+		return ".md"
+	}
+	return "." + format
+}
+
+// Write 以指定格式写出文档。
+func Write(w io.Writer, format string, doc Document) error {
+	switch format {
+	case FormatJSON:
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		return enc.Encode(doc)
+	case FormatMarkdown:
+		return writeMarkdown(w, doc)
+	case FormatCSV:
+		return writeCSV(w, doc)
+	}
+	return fmt.Errorf("不支持的导出格式: %s", format)
+}
+
+func writeMarkdown(w io.Writer, doc Document) error {
+	var b strings.Builder
+	b.WriteString("## 命名候选\n\n")
+	fmt.Fprintf(&b, "- 描述：%s\n", markdownInline(doc.Description))
+	fmt.Fprintf(&b, "- 命名类型：%s\n", labelled(doc.KindLabel, string(doc.Kind)))
+	if doc.Style != "" {
+		fmt.Fprintf(&b, "- 命名格式：%s\n", labelled(doc.StyleLabel, string(doc.Style)))
+	}
+	provider := doc.Provider
+	if doc.Model != "" {
+		provider = fmt.Sprintf("%s（%s）", doc.Provider, doc.Model)
+	}
+	fmt.Fprintf(&b, "- 提供方：%s\n", provider)
+	fmt.Fprintf(&b, "- 生成时间：%s\n\n", doc.GeneratedAt.Format("2006-01-02 15:04:05"))
+
+	b.WriteString("| # | 名称 |\n| --- | --- |\n")
+	for i, name := range doc.Candidates {
+		fmt.Fprintf(&b, "| %d | `%s` |\n", i+1, strings.ReplaceAll(name, "|", `\|`))
+	}
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+func writeCSV(w io.Writer, doc Document) error {
+	cw := csv.NewWriter(w)
+	if err := cw.Write([]string{"index", "name", "kind", "style", "provider", "model", "description", "generated_at"}); err != nil {
+		return err
+	}
+	generatedAt := doc.GeneratedAt.Format(time.RFC3339)
+	for i, name := range doc.Candidates {
+		record := []string{
+			strconv.Itoa(i + 1),
+			name,
+			string(doc.Kind),
+			string(doc.Style),
+			doc.Provider,
+			doc.Model,
+			doc.Description,
+			generatedAt,
+		}
+		if err := cw.Write(record); err != nil {
+			return err
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+func labelled(label, id string) string {
+	if strings.TrimSpace(label) == "" || label == id {
+		return id
+	}
+	return fmt.Sprintf("%s (%s)", label, id)
+}
+
+// markdownInline 折叠多行描述，避免破坏列表结构。
+func markdownInline(text string) string {
+	return strings.Join(strings.Fields(text), " ")
+}
@@ -0,0 +1,143 @@
+package glossary
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/yanzzp/name-sprout/internal/casing"
+)
+
+// PreferredTerm 描述一个推荐术语及其应避免的同义写法。
+type PreferredTerm struct {
+	Term  string   `yaml:"term"`
+	Avoid []string `yaml:"avoid"`
+	Note  string   `yaml:"note"`
+}
+
+// Glossary 汇总项目约定的领域词汇，用于约束提示词并过滤候选名称。
+type Glossary struct {
+	Preferred     []PreferredTerm   `yaml:"preferred"`
+	Banned        []string          `yaml:"banned"`
+	Abbreviations map[string]string `yaml:"abbreviations"`
+}
+
+// Violation 记录名称与术语表冲突的原因。
+type Violation struct {
+	Term      string
+	Preferred string
+}
+
+// String 返回便于展示的冲突描述。
+func (v Violation) String() string {
+	if v.Preferred != "" {
+		return fmt.Sprintf("使用了 %q，应改用 %q", v.Term, v.Preferred)
+	}
+	return fmt.Sprintf("使用了禁用词 %q", v.Term)
+}
+
+// Load 从指定路径读取术语表。
+func Load(path string) (*Glossary, error) {
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("读取术语表失败: %w", err)
+	}
+
+	var g Glossary
+	if err := yaml.Unmarshal(raw, &g); err != nil {
+		return nil, fmt.Errorf("解析术语表失败: %w", err)
+	}
+
+	for i, term := range g.Preferred {
+		if strings.TrimSpace(term.Term) == "" {
+			return nil, fmt.Errorf("术语表 preferred[%d] 缺少 term 字段", i)
+		}
+	}
+
+	return &g, nil
+}
+
+// Prompt 将术语表渲染为提示词片段，术语表为空时返回空字符串。
+func (g *Glossary) Prompt() string {
+	if g == nil {
+		return ""
+	}
+
+	var lines []string
+	for _, term := range g.Preferred {
+		line := fmt.Sprintf("- 优先使用 `%s`", term.Term)
+		if len(term.Avoid) > 0 {
+			line += fmt.Sprintf("，避免 %s", quoteJoin(term.Avoid))
+		}
+		if note := strings.TrimSpace(term.Note); note != "" {
+			line += fmt.Sprintf("（%s）", note)
+		}
+		lines = append(lines, line+"。")
+	}
+	if len(g.Banned) > 0 {
+		lines = append(lines, fmt.Sprintf("- 禁止使用：%s。", quoteJoin(g.Banned)))
+	}
+	if len(g.Abbreviations) > 0 {
+		keys := make([]string, 0, len(g.Abbreviations))
+		for abbr := range g.Abbreviations {
+			keys = append(keys, abbr)
+		}
+		sort.Strings(keys)
+		parts := make([]string, 0, len(keys))
+		for _, abbr := range keys {
+			parts = append(parts, fmt.Sprintf("`%s`（%s）", abbr, g.Abbreviations[abbr]))
+		}
+		lines = append(lines, fmt.Sprintf("- 允许使用的缩写：%s。", strings.Join(parts, "、")))
+	}
+	return strings.Join(lines, "\n")
+}
+
+// Check 返回名称违反术语表的全部原因。
+func (g *Glossary) Check(name string) []Violation {
+	if g == nil {
+		return nil
+	}
+
+	words := casing.Split(name)
+	var violations []Violation
+	for _, banned := range g.Banned {
+		if casing.ContainsSequence(words, casing.Split(banned)) {
+			violations = append(violations, Violation{Term: banned})
+		}
+	}
+	for _, term := range g.Preferred {
+		for _, avoid := range term.Avoid {
+			if casing.ContainsSequence(words, casing.Split(avoid)) {
+				violations = append(violations, Violation{Term: avoid, Preferred: term.Term})
+			}
+		}
+	}
+	return violations
+}
+
+// Filter 剔除违反术语表的名称，返回保留与剔除的两组结果。
+func (g *Glossary) Filter(names []string) (kept, dropped []string) {
+	if g == nil {
+		return names, nil
+	}
+	kept = make([]string, 0, len(names))
+	for _, name := range names {
+		if len(g.Check(name)) > 0 {
+			dropped = append(dropped, name)
+			continue
+		}
+		kept = append(kept, name)
+	}
+	return kept, dropped
+}
+
+func quoteJoin(terms []string) string {
+	quoted := make([]string, 0, len(terms))
+	for _, term := range terms {
+		quoted = append(quoted, fmt.Sprintf("`%s`", term))
+	}
+	return strings.Join(quoted, "、")
+}
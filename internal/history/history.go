@@ -0,0 +1,237 @@
+package history
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/yanzzp/name-sprout/internal/providers"
+)
+
+// Entry 记录一次名称生成及其后续操作。
+type Entry struct {
+	ID          string                `json:"id"`
+	Time        time.Time             `json:"time"`
+	Source      string                `json:"source,omitempty"`
+	Description string                `json:"description"`
+	Kind        providers.NameKind    `json:"kind"`
+	Style       providers.NamingStyle `json:"style,omitempty"`
+	Provider    string                `json:"provider"`
+	Model       string                `json:"model,omitempty"`
+	Candidates  []string              `json:"candidates"`
+	Copied      []string              `json:"copied,omitempty"`
+	Pinned      []string              `json:"pinned,omitempty"`
+}
+
+// 历史文件中的事件类型。文件只追加写入，读取时按事件重放得到最终状态。
+const (
+	eventGenerate = "generate"
+	eventCopy     = "copy"
+	eventPin      = "pin"
+	eventUnpin    = "unpin"
+)
+
+type record struct {
+	Event string `json:"event"`
+	Entry
+	Name string `json:"name,omitempty"`
+}
+
+// Store 以 JSONL 文件保存生成历史。
+type Store struct {
+	path string
+	mu   sync.Mutex
+}
+
+// DefaultPath 返回遵循 XDG 规范的默认历史文件路径。
+func DefaultPath() (string, error) {
+	dir := os.Getenv("XDG_DATA_HOME")
+	if dir == "" {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return "", fmt.Errorf("无法确定用户目录: %w", err)
+		}
+		dir = filepath.Join(home, ".local", "share")
+	}
+	return filepath.Join(dir, "namesprout", "history.jsonl"), nil
+}
+
+// Open 返回指定路径的历史存储，文件在首次写入时创建。
+func Open(path string) *Store {
+	return &Store{path: path}
+}
+
+// Path 返回历史文件路径。
+func (s *Store) Path() string {
+	return s.path
+}
+
+// Record 追加一条生成记录，并补全 ID 与时间。
+func (s *Store) Record(entry Entry) (Entry, error) {
+	if entry.Time.IsZero() {
+		entry.Time = time.Now()
+	}
+	if entry.ID == "" {
+		entry.ID = strconv.FormatInt(entry.Time.UnixNano(), 36)
+	}
+	entry.Copied = nil
+	entry.Pinned = nil
+	return entry, s.append(record{Event: eventGenerate, Entry: entry})
+}
+
+// MarkCopied 记录某条生成结果中被复制的名称。
+func (s *Store) MarkCopied(id, name string) error {
+	return s.append(record{Event: eventCopy, Entry: Entry{ID: id, Time: time.Now()}, Name: name})
+}
+
+// MarkPinned 记录某条生成结果中的名称被固定或取消固定。
+func (s *Store) MarkPinned(id, name string, pinned bool) error {
+	event := eventPin
+	if !pinned {
+		event = eventUnpin
+	}
+	return s.append(record{Event: event, Entry: Entry{ID: id, Time: time.Now()}, Name: name})
+}
+
+func (s *Store) append(rec record) error {
+	if s == nil {
+		return nil
+	}
+	payload, err := json.Marshal(rec)
+	if err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if err := os.MkdirAll(filepath.Dir(s.path), 0o755); err != nil {
+		return fmt.Errorf("创建历史目录失败: %w", err)
+	}
+	f, err := os.OpenFile(s.path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0o600)
+	if err != nil {
+		return fmt.Errorf("打开历史文件失败: %w", err)
+	}
+	defer f.Close()
+	if _, err := f.Write(append(payload, '\n')); err != nil {
+		return fmt.Errorf("写入历史文件失败: %w", err)
+	}
+	return nil
+}
+
+// List 读取全部历史，按时间倒序排列。无法解析的行会被跳过。
+func (s *Store) List() ([]Entry, error) {
+	if s == nil {
+		return nil, nil
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	f, err := os.Open(s.path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("打开历史文件失败: %w", err)
+	}
+	defer f.Close()
+
+	var (
+		order   []string
+		entries = make(map[string]*Entry)
+	)
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(make([]byte, 0, 64*1024), 4*1024*1024)
+	for scanner.Scan() {
+		var rec record
+		if err := json.Unmarshal(scanner.Bytes(), &rec); err != nil {
+			continue
+		}
+		switch rec.Event {
+		case eventGenerate:
+			entry := rec.Entry
+			if _, ok := entries[entry.ID]; !ok {
+				order = append(order, entry.ID)
+			}
+			entries[entry.ID] = &entry
+		case eventCopy:
+			if entry, ok := entries[rec.ID]; ok && !contains(entry.Copied, rec.Name) {
+				entry.Copied = append(entry.Copied, rec.Name)
+			}
+		case eventPin:
+			if entry, ok := entries[rec.ID]; ok && !contains(entry.Pinned, rec.Name) {
+				entry.Pinned = append(entry.Pinned, rec.Name)
+			}
+		case eventUnpin:
+			if entry, ok := entries[rec.ID]; ok {
+				entry.Pinned = remove(entry.Pinned, rec.Name)
+			}
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("读取历史文件失败: %w", err)
+	}
+
+	result := make([]Entry, 0, len(order))
+	for _, id := range order {
+		result = append(result, *entries[id])
+	}
+	sort.SliceStable(result, func(i, j int) bool { return result[i].Time.After(result[j].Time) })
+	return result, nil
+}
+
+// Item 是展开后的一条候选名称，便于搜索与复制。
+type Item struct {
+	Name   string
+	Copied bool
+	Pinned bool
+	Entry  *Entry
+}
+
+// Search 将历史展开为候选名称并按关键字过滤，关键字同时匹配名称与描述，忽略大小写。
+func Search(entries []Entry, query string) []Item {
+	query = strings.ToLower(strings.TrimSpace(query))
+	var items []Item
+	for i := range entries {
+		entry := &entries[i]
+		descMatch := query == "" || strings.Contains(strings.ToLower(entry.Description), query)
+		for _, name := range entry.Candidates {
+			if !descMatch && !strings.Contains(strings.ToLower(name), query) {
+				continue
+			}
+			items = append(items, Item{
+				Name:   name,
+				Copied: contains(entry.Copied, name),
+				Pinned: contains(entry.Pinned, name),
+				Entry:  entry,
+			})
+		}
+	}
+	return items
+}
+
+func contains(values []string, target string) bool {
+	for _, value := range values {
+		if value == target {
+			return true
+		}
+	}
+	return false
+}
+
+func remove(values []string, target string) []string {
+	kept := values[:0]
+	for _, value := range values {
+		if value != target {
+			kept = append(kept, value)
+		}
+	}
+	return kept
+}
@@ -0,0 +1,262 @@
+package jsonrpc
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/textproto"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// JSON-RPC 2.0 预定义的错误码。
+const (
+	CodeParseError     = -32700
+	CodeInvalidRequest = -32600
+	CodeMethodNotFound = -32601
+	CodeInvalidParams  = -32602
+	CodeInternalError  = -32603
+)
+
+// ErrClosed 由 Handler 在处理通知时返回，用于通知 Serve 正常退出（例如 LSP 的 exit）。
+var ErrClosed = errors.New("jsonrpc: 连接已关闭")
+
+// Message 是读取到的一条 JSON-RPC 消息，可能是请求、通知或响应。
+type Message struct {
+	JSONRPC string          `json:"jsonrpc"`
+	ID      json.RawMessage `json:"id,omitempty"`
+	Method  string          `json:"method,omitempty"`
+	Params  json.RawMessage `json:"params,omitempty"`
+	Result  json.RawMessage `json:"result,omitempty"`
+	Error   *Error          `json:"error,omitempty"`
+}
+
+// IsNotification 判断消息是否为无需响应的通知。
+func (m *Message) IsNotification() bool {
+	return len(m.ID) == 0
+}
+
+// Error 是 JSON-RPC 错误对象，Handler 返回该类型时会原样回传错误码。
+type Error struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+func (e *Error) Error() string {
+	return fmt.Sprintf("jsonrpc %d: %s", e.Code, e.Message)
+}
+
+// Errorf 构造带错误码的 JSON-RPC 错误。
+func Errorf(code int, format string, args ...any) *Error {
+	return &Error{Code: code, Message: fmt.Sprintf(format, args...)}
+}
+
+// Stream 负责消息的分帧读写。
+type Stream interface {
+	Read() ([]byte, error)
+	Write(payload []byte) error
+}
+
+// NewHeaderStream 使用 LSP 的 Content-Length 头部分帧。
+func NewHeaderStream(r io.Reader, w io.Writer) Stream {
+	return &headerStream{r: bufio.NewReader(r), w: w}
+}
+
+// NewLineStream 使用换行符分帧，每行一条 JSON 消息（MCP stdio 传输）。
+func NewLineStream(r io.Reader, w io.Writer) Stream {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), 16*1024*1024)
+	return &lineStream{scanner: scanner, w: w}
+}
+
+type headerStream struct {
+	r  *bufio.Reader
+	mu sync.Mutex
+	w  io.Writer
+}
+
+func (s *headerStream) Read() ([]byte, error) {
+	header, err := textproto.NewReader(s.r).ReadMIMEHeader()
+	if err != nil {
+		return nil, err
+	}
+	length, err := strconv.Atoi(strings.TrimSpace(header.Get("Content-Length")))
+	if err != nil || length < 0 {
+		return nil, fmt.Errorf("无效的 Content-Length: %q", header.Get("Content-Length"))
+	}
+	payload := make([]byte, length)
+	if _, err := io.ReadFull(s.r, payload); err != nil {
+		return nil, err
+	}
+	return payload, nil
+}
+
+func (s *headerStream) Write(payload []byte) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, err := fmt.Fprintf(s.w, "Content-Length: %d\r\n\r\n", len(payload)); err != nil {
+		return err
+	}
+	_, err := s.w.Write(payload)
+	return err
+}
+
+type lineStream struct {
+	scanner *bufio.Scanner
+	mu      sync.Mutex
+	w       io.Writer
+}
+
+func (s *lineStream) Read() ([]byte, error) {
+	for s.scanner.Scan() {
+		line := strings.TrimSpace(s.scanner.Text())
+		if line != "" {
+			return []byte(line), nil
+		}
+	}
+	if err := s.scanner.Err(); err != nil {
+		return nil, err
+	}
+	return nil, io.EOF
+}
+
+func (s *lineStream) Write(payload []byte) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	_, err := s.w.Write(append(payload, '\n'))
+	return err
+}
+
+// Handler 处理请求或通知。对于通知，返回值会被忽略。
+type Handler func(ctx context.Context, conn *Conn, msg *Message) (any, error)
+
+// Conn 是一条双向 JSON-RPC 连接。
+type Conn struct {
+	stream Stream
+
+	mu      sync.Mutex
+	pending map[string]context.CancelFunc
+}
+
+// NewConn 基于分帧流创建连接。
+func NewConn(stream Stream) *Conn {
+	return &Conn{stream: stream, pending: make(map[string]context.CancelFunc)}
+}
+
+// Cancel 取消仍在处理中的请求 id 的上下文，返回是否找到该请求。
+// 协议层的取消通知（如 LSP 的 $/cancelRequest）由 Handler 解析后调用它。
+func (c *Conn) Cancel(id json.RawMessage) bool {
+	c.mu.Lock()
+	cancel, ok := c.pending[idKey(id)]
+	c.mu.Unlock()
+	if ok {
+		cancel()
+	}
+	return ok
+}
+
+// idKey 将请求 id 规范化为去除空白的 JSON 文本，作为登记请求的 key。
+func idKey(id json.RawMessage) string {
+	var buf bytes.Buffer
+	if err := json.Compact(&buf, id); err != nil {
+		return string(id)
+	}
+	return buf.String()
+}
+
+// Notify 向对端发送通知。
+func (c *Conn) Notify(method string, params any) error {
+	return c.send(struct {
+		JSONRPC string `json:"jsonrpc"`
+		Method  string `json:"method"`
+		Params  any    `json:"params,omitempty"`
+	}{"2.0", method, params})
+}
+
+func (c *Conn) reply(id json.RawMessage, result any, err error) error {
+	if err != nil {
+		var rpcErr *Error
+		if !errors.As(err, &rpcErr) {
+			rpcErr = &Error{Code: CodeInternalError, Message: err.Error()}
+		}
+		return c.send(struct {
+			JSONRPC string          `json:"jsonrpc"`
+			ID      json.RawMessage `json:"id"`
+			Error   *Error          `json:"error"`
+		}{"2.0", id, rpcErr})
+	}
+	return c.send(struct {
+		JSONRPC string          `json:"jsonrpc"`
+		ID      json.RawMessage `json:"id"`
+		Result  any             `json:"result"`
+	}{"2.0", id, result})
+}
+
+func (c *Conn) send(v any) error {
+	payload, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+	return c.stream.Write(payload)
+}
+
+// Serve 持续读取消息直至流结束或 ctx 取消。通知按到达顺序同步处理，
+// 请求在独立的 goroutine 中处理，以免慢请求阻塞后续的文档同步。
+func (c *Conn) Serve(ctx context.Context, handler Handler) error {
+	var wg sync.WaitGroup
+	defer wg.Wait()
+
+	for {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		payload, err := c.stream.Read()
+		if err != nil {
+			if errors.Is(err, io.EOF) {
+				return nil
+			}
+			return err
+		}
+
+		var msg Message
+		if err := json.Unmarshal(payload, &msg); err != nil {
+			_ = c.reply(json.RawMessage("null"), nil, Errorf(CodeParseError, "无法解析消息: %v", err))
+			continue
+		}
+		if msg.Method == "" {
+			// 本实现不主动发起请求，忽略对端的响应。
+			continue
+		}
+		if msg.IsNotification() {
+			if _, err := handler(ctx, c, &msg); errors.Is(err, ErrClosed) {
+				return nil
+			}
+			continue
+		}
+
+		// 在读取下一条消息前登记请求，保证随后到达的取消通知一定能找到它。
+		reqCtx, cancel := context.WithCancel(ctx)
+		key := idKey(msg.ID)
+		c.mu.Lock()
+		c.pending[key] = cancel
+		c.mu.Unlock()
+
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			defer func() {
+				c.mu.Lock()
+				delete(c.pending, key)
+				c.mu.Unlock()
+				cancel()
+			}()
+			result, err := handler(reqCtx, c, &msg)
+			_ = c.reply(msg.ID, result, err)
+		}()
+	}
+}
@@ -0,0 +1,274 @@
+package lint
+
+import (
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/yanzzp/name-sprout/internal/casing"
+	"github.com/yanzzp/name-sprout/internal/glossary"
+	"github.com/yanzzp/name-sprout/internal/providers"
+)
+
+// Kind 表示被检查标识符的类别。
+type Kind string
+
+const (
+	KindFunction Kind = "function"
+	KindVariable Kind = "variable"
+	KindConstant Kind = "constant"
+)
+
+// AllKinds 列出 lint 支持的标识符类别。
+var AllKinds = []Kind{KindFunction, KindVariable, KindConstant}
+
+// NameKind 返回用于向模型请求新名称的命名类型。
+func (k Kind) NameKind() providers.NameKind {
+	if k == KindFunction {
+		return providers.NameKindFunction
+	}
+	return providers.NameKindVariable
+}
+
+// GoStyle 为未配置 lint.styles 时各类别使用的格式，遵循 Go 的惯例：
+// 标识符一律使用驼峰，首字母大小写只表示导出性（见 matchesStyle）。
+const GoStyle = providers.NamingStyleLowerCamel
+
+// 规则标识，同时用作 SARIF 中的 ruleId。
+const (
+	RuleStyle    = "naming-style"
+	RuleGlossary = "glossary"
+)
+
+// Rules 描述 lint 的检查规则。
+type Rules struct {
+	// Styles 指定各类别应遵循的命名格式，缺失的类别不做格式检查。
+	Styles   map[Kind]providers.NamingStyle
+	Glossary *glossary.Glossary
+}
+
+// Issue 描述一个不符合规则的标识符。
+type Issue struct {
+	Path        string                `json:"path"`
+	Line        int                   `json:"line"`
+	Column      int                   `json:"column"`
+	Name        string                `json:"name"`
+	Kind        Kind                  `json:"kind"`
+	Rule        string                `json:"rule"`
+	Message     string                `json:"message"`
+	Style       providers.NamingStyle `json:"style,omitempty"`
+	Fix         string                `json:"fix,omitempty"`
+	Suggestions []string              `json:"suggestions,omitempty"`
+}
+
+// Run 检查 patterns 覆盖的 Go 源码。以 "/..." 结尾的路径会递归遍历子目录。
+func Run(patterns []string, rules Rules) ([]Issue, error) {
+	if len(patterns) == 0 {
+		patterns = []string{"./..."}
+	}
+
+	files, err := collectFiles(patterns)
+	if err != nil {
+		return nil, err
+	}
+
+	var issues []Issue
+	fset := token.NewFileSet()
+	for _, path := range files {
+		file, err := parser.ParseFile(fset, path, nil, parser.SkipObjectResolution)
+		if err != nil {
+			return nil, fmt.Errorf("解析 %s 失败: %w", path, err)
+		}
+		issues = append(issues, checkFile(fset, file, rules)...)
+	}
+
+	sort.SliceStable(issues, func(i, j int) bool {
+		if issues[i].Path != issues[j].Path {
+			return issues[i].Path < issues[j].Path
+		}
+		if issues[i].Line != issues[j].Line {
+			return issues[i].Line < issues[j].Line
+		}
+		return issues[i].Column < issues[j].Column
+	})
+	return issues, nil
+}
+
+func collectFiles(patterns []string) ([]string, error) {
+	seen := make(map[string]struct{})
+	var files []string
+	add := func(path string) {
+		if _, ok := seen[path]; ok {
+			return
+		}
+		seen[path] = struct{}{}
+		files = append(files, path)
+	}
+
+	for _, pattern := range patterns {
+		recursive := false
+		root := pattern
+		if strings.HasSuffix(pattern, "/...") || pattern == "..." {
+			recursive = true
+			root = strings.TrimSuffix(strings.TrimSuffix(pattern, "..."), "/")
+			if root == "" {
+				root = "."
+			}
+		}
+
+		info, err := os.Stat(root)
+		if err != nil {
+			return nil, fmt.Errorf("读取 %s 失败: %w", root, err)
+		}
+		if !info.IsDir() {
+			add(root)
+			continue
+		}
+
+		err = filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+			if err != nil {
+				return err
+			}
+			if d.IsDir() {
+				if path != root && (!recursive || skipDir(d.Name())) {
+					return filepath.SkipDir
+				}
+				return nil
+			}
+			if strings.HasSuffix(path, ".go") {
+				add(path)
+			}
+			return nil
+		})
+		if err != nil {
+			return nil, fmt.Errorf("遍历 %s 失败: %w", root, err)
+		}
+	}
+	return files, nil
+}
+
+// skipDir 遵循 go 命令的约定，忽略 vendor、testdata 以及以 . 或 _ 开头的目录。
+func skipDir(name string) bool {
+	return name == "vendor" || name == "testdata" || strings.HasPrefix(name, ".") || strings.HasPrefix(name, "_")
+}
+
+func checkFile(fset *token.FileSet, file *ast.File, rules Rules) []Issue {
+	var issues []Issue
+	testFile := strings.HasSuffix(fset.Position(file.Pos()).Filename, "_test.go")
+
+	check := func(id *ast.Ident, kind Kind) {
+		if id == nil || id.Name == "_" {
+			return
+		}
+		pos := fset.Position(id.Pos())
+		base := Issue{Path: pos.Filename, Line: pos.Line, Column: pos.Column, Name: id.Name, Kind: kind}
+
+		if style, ok := rules.Styles[kind]; ok && !matchesStyle(id.Name, style) {
+			issue := base
+			issue.Rule = RuleStyle
+			issue.Style = style
+			issue.Fix = fixName(id.Name, style)
+			issue.Message = fmt.Sprintf("%s %s 不符合命名格式 %s", kindLabel(kind), id.Name, style)
+			issues = append(issues, issue)
+		}
+		for _, violation := range rules.Glossary.Check(id.Name) {
+			issue := base
+			issue.Rule = RuleGlossary
+			issue.Message = fmt.Sprintf("%s %s %s", kindLabel(kind), id.Name, violation)
+			issues = append(issues, issue)
+		}
+	}
+
+	ast.Inspect(file, func(n ast.Node) bool {
+		switch node := n.(type) {
+		case *ast.FuncDecl:
+			if !isSpecialFunc(node, testFile) {
+				check(node.Name, KindFunction)
+			}
+		case *ast.GenDecl:
+			kind := KindVariable
+			if node.Tok == token.CONST {
+				kind = KindConstant
+			}
+			for _, spec := range node.Specs {
+				if vs, ok := spec.(*ast.ValueSpec); ok {
+					for _, name := range vs.Names {
+						check(name, kind)
+					}
+				}
+			}
+		case *ast.AssignStmt:
+			if node.Tok == token.DEFINE {
+				for _, expr := range node.Lhs {
+					if id, ok := expr.(*ast.Ident); ok {
+						check(id, KindVariable)
+					}
+				}
+			}
+		case *ast.RangeStmt:
+			if node.Tok == token.DEFINE {
+				for _, expr := range []ast.Expr{node.Key, node.Value} {
+					if id, ok := expr.(*ast.Ident); ok {
+						check(id, KindVariable)
+					}
+				}
+			}
+		}
+		return true
+	})
+	return issues
+}
+
+// fixName 将名称转换为目标格式，并保留导出标识符的首字母大写，避免修复建议改变导出性、破坏其它包的引用。
+func fixName(name string, style providers.NamingStyle) string {
+	fixed := casing.Convert(name, style)
+	if !token.IsExported(name) || token.IsExported(fixed) {
+		return fixed
+	}
+	r, size := utf8.DecodeRuneInString(fixed)
+	return string(unicode.ToUpper(r)) + fixed[size:]
+}
+
+// matchesStyle 在 Go 中放宽驼峰格式的首字母要求，因为首字母大小写决定的是导出性。
+func matchesStyle(name string, style providers.NamingStyle) bool {
+	switch style {
+	case providers.NamingStyleLowerCamel, providers.NamingStylePascal:
+		return casing.Matches(name, providers.NamingStyleLowerCamel) || casing.Matches(name, providers.NamingStylePascal)
+	}
+	return casing.Matches(name, style)
+}
+
+func isSpecialFunc(fn *ast.FuncDecl, testFile bool) bool {
+	name := fn.Name.Name
+	if fn.Recv == nil && (name == "init" || name == "main") {
+		return true
+	}
+	if !testFile {
+		return false
+	}
+	for _, prefix := range []string{"Test", "Benchmark", "Example", "Fuzz"} {
+		if strings.HasPrefix(name, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+func kindLabel(kind Kind) string {
+	switch kind {
+	case KindFunction:
+		return "函数"
+	case KindConstant:
+		return "常量"
+	default:
+		return "变量"
+	}
+}
@@ -0,0 +1,161 @@
+package lint
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"path/filepath"
+	"strings"
+)
+
+// 支持的输出格式。
+const (
+	FormatText  = "text"
+	FormatJSON  = "json"
+	FormatSARIF = "sarif"
+)
+
+// CheckFormat 校验输出格式，便于在扫描之前尽早报错。
+func CheckFormat(format string) error {
+	switch format {
+	case "", FormatText, FormatJSON, FormatSARIF:
+		return nil
+	}
+	return fmt.Errorf("不支持的输出格式: %s（可选 text / json / sarif）", format)
+}
+
+// Write 以指定格式输出检查结果。
+func Write(w io.Writer, format string, issues []Issue) error {
+	switch format {
+	case "", FormatText:
+		return writeText(w, issues)
+	case FormatJSON:
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		if issues == nil {
+			issues = []Issue{}
+		}
+		return enc.Encode(issues)
+	case FormatSARIF:
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		return enc.Encode(buildSARIF(issues))
+	}
+	return fmt.Errorf("不支持的输出格式: %s", format)
+}
+
+func writeText(w io.Writer, issues []Issue) error {
+	for _, issue := range issues {
+		line := fmt.Sprintf("%s:%d:%d: %s [%s]", issue.Path, issue.Line, issue.Column, issue.Message, issue.Rule)
+		var hints []string
+		if issue.Fix != "" && issue.Fix != issue.Name {
+			hints = append(hints, issue.Fix)
+		}
+		hints = append(hints, issue.Suggestions...)
+		if len(hints) > 0 {
+			line += fmt.Sprintf("，建议：%s", strings.Join(hints, "、"))
+		}
+		if _, err := fmt.Fprintln(w, line); err != nil {
+			return err
+		}
+	}
+	_, err := fmt.Fprintf(w, "共发现 %d 个问题。\n", len(issues))
+	return err
+}
+
+type sarifLog struct {
+	Schema  string     `json:"$schema"`
+	Version string     `json:"version"`
+	Runs    []sarifRun `json:"runs"`
+}
+
+type sarifRun struct {
+	Tool    sarifTool     `json:"tool"`
+	Results []sarifResult `json:"results"`
+}
+
+type sarifTool struct {
+	Driver sarifDriver `json:"driver"`
+}
+
+type sarifDriver struct {
+	Name           string      `json:"name"`
+	InformationURI string      `json:"informationUri"`
+	Rules          []sarifRule `json:"rules"`
+}
+
+type sarifRule struct {
+	ID               string       `json:"id"`
+	ShortDescription sarifMessage `json:"shortDescription"`
+}
+
+type sarifMessage struct {
+	Text string `json:"text"`
+}
+
+type sarifResult struct {
+	RuleID    string          `json:"ruleId"`
+	Level     string          `json:"level"`
+	Message   sarifMessage    `json:"message"`
+	Locations []sarifLocation `json:"locations"`
+}
+
+type sarifLocation struct {
+	PhysicalLocation sarifPhysicalLocation `json:"physicalLocation"`
+}
+
+type sarifPhysicalLocation struct {
+	ArtifactLocation sarifArtifactLocation `json:"artifactLocation"`
+	Region           sarifRegion           `json:"region"`
+}
+
+type sarifArtifactLocation struct {
+	URI string `json:"uri"`
+}
+
+type sarifRegion struct {
+	StartLine   int `json:"startLine"`
+	StartColumn int `json:"startColumn"`
+	EndColumn   int `json:"endColumn"`
+}
+
+func buildSARIF(issues []Issue) sarifLog {
+	results := make([]sarifResult, 0, len(issues))
+	for _, issue := range issues {
+		text := issue.Message
+		if len(issue.Suggestions) > 0 {
+			text += fmt.Sprintf("（建议：%s）", strings.Join(issue.Suggestions, "、"))
+		}
+		results = append(results, sarifResult{
+			RuleID:  issue.Rule,
+			Level:   "warning",
+			Message: sarifMessage{Text: text},
+			Locations: []sarifLocation{{
+				PhysicalLocation: sarifPhysicalLocation{
+					ArtifactLocation: sarifArtifactLocation{URI: filepath.ToSlash(issue.Path)},
+					Region: sarifRegion{
+						StartLine:   issue.Line,
+						StartColumn: issue.Column,
+						EndColumn:   issue.Column + len(issue.Name),
+					},
+				},
+			}},
+		})
+	}
+
+	return sarifLog{
+		Schema:  "https://json.schemastore.org/sarif-2.1.0.json",
+		Version: "2.1.0",
+		Runs: []sarifRun{{
+			Tool: sarifTool{Driver: sarifDriver{
+				Name:           "namesprout",
+				InformationURI: "https://github.com/yanzzp/name-sprout",
+				Rules: []sarifRule{
+					{ID: RuleStyle, ShortDescription: sarifMessage{Text: "标识符不符合配置的命名格式"}},
+					{ID: RuleGlossary, ShortDescription: sarifMessage{Text: "标识符违反项目术语表"}},
+				},
+			}},
+			Results: results,
+		}},
+	}
+}
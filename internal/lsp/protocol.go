@@ -0,0 +1,155 @@
+package lsp
+
+import (
+	"encoding/json"
+	"net/url"
+	"path/filepath"
+	"strings"
+	"unicode/utf16"
+	"unicode/utf8"
+)
+
+// 以下为本服务器用到的 LSP 协议子集。
+
+type position struct {
+	Line      int `json:"line"`
+	Character int `json:"character"`
+}
+
+type textRange struct {
+	Start position `json:"start"`
+	End   position `json:"end"`
+}
+
+type textDocumentIdentifier struct {
+	URI string `json:"uri"`
+}
+
+type textDocumentItem struct {
+	URI        string `json:"uri"`
+	LanguageID string `json:"languageId"`
+	Version    int    `json:"version"`
+	Text       string `json:"text"`
+}
+
+type didOpenParams struct {
+	TextDocument textDocumentItem `json:"textDocument"`
+}
+
+type didChangeParams struct {
+	TextDocument struct {
+		URI     string `json:"uri"`
+		Version int    `json:"version"`
+	} `json:"textDocument"`
+	ContentChanges []struct {
+		Range *textRange `json:"range,omitempty"`
+		Text  string     `json:"text"`
+	} `json:"contentChanges"`
+}
+
+type didCloseParams struct {
+	TextDocument textDocumentIdentifier `json:"textDocument"`
+}
+
+// codeRequestCancelled 为 LSP 约定的请求被取消时的错误码。
+const codeRequestCancelled = -32800
+
+type cancelParams struct {
+	ID json.RawMessage `json:"id"`
+}
+
+// codeActionTriggerAutomatic 表示编辑器在光标移动等场景自动请求的代码操作。
+const codeActionTriggerAutomatic = 2
+
+type codeActionParams struct {
+	TextDocument textDocumentIdentifier `json:"textDocument"`
+	Range        textRange              `json:"range"`
+	Context      struct {
+		Only        []string `json:"only,omitempty"`
+		TriggerKind int      `json:"triggerKind,omitempty"`
+	} `json:"context"`
+}
+
+type textEdit struct {
+	Range   textRange `json:"range"`
+	NewText string    `json:"newText"`
+}
+
+type workspaceEdit struct {
+	Changes map[string][]textEdit `json:"changes"`
+}
+
+type codeAction struct {
+	Title    string         `json:"title"`
+	Kind     string         `json:"kind"`
+	Edit     *workspaceEdit `json:"edit,omitempty"`
+	Disabled *struct {
+		Reason string `json:"reason"`
+	} `json:"disabled,omitempty"`
+}
+
+const codeActionKindRefactor = "refactor.rewrite"
+
+// offsetOf 将 LSP 位置（UTF-16 码元）转换为字节偏移，越界时截断到行尾或文本末尾。
+func offsetOf(text string, pos position) int {
+	offset := 0
+	for line := 0; line < pos.Line; line++ {
+		next := strings.IndexByte(text[offset:], '\n')
+		if next < 0 {
+			return len(text)
+		}
+		offset += next + 1
+	}
+	units := 0
+	for offset < len(text) && units < pos.Character {
+		r, size := utf8.DecodeRuneInString(text[offset:])
+		if r == '\n' {
+			break
+		}
+		units += utf16.RuneLen(r)
+		offset += size
+	}
+	return offset
+}
+
+// positionOf 将字节偏移转换为 LSP 位置。
+func positionOf(text string, offset int) position {
+	if offset > len(text) {
+		offset = len(text)
+	}
+	var pos position
+	lineStart := 0
+	for i := 0; i < offset; i++ {
+		if text[i] == '\n' {
+			pos.Line++
+			lineStart = i + 1
+		}
+	}
+	for _, r := range text[lineStart:offset] {
+		pos.Character += utf16.RuneLen(r)
+	}
+	return pos
+}
+
+// uriToPath 将 file:// URI 转换为本地路径。
+func uriToPath(uri string) string {
+	parsed, err := url.Parse(uri)
+	if err != nil || parsed.Scheme != "file" {
+		return uri
+	}
+	path := parsed.Path
+	// Windows: file:///C:/foo -> C:/foo
+	if len(path) >= 3 && path[0] == '/' && path[2] == ':' {
+		path = path[1:]
+	}
+	return filepath.FromSlash(path)
+}
+
+// pathToURI 将本地路径转换为 file:// URI。
+func pathToURI(path string) string {
+	path = filepath.ToSlash(path)
+	if !strings.HasPrefix(path, "/") {
+		path = "/" + path
+	}
+	return (&url.URL{Scheme: "file", Path: path}).String()
+}
@@ -0,0 +1,439 @@
+package lsp
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"go/scanner"
+	"go/token"
+	"io"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+	"unicode"
+
+	"github.com/yanzzp/name-sprout/internal/app"
+	"github.com/yanzzp/name-sprout/internal/codectx"
+	"github.com/yanzzp/name-sprout/internal/jsonrpc"
+	"github.com/yanzzp/name-sprout/internal/providers"
+	"github.com/yanzzp/name-sprout/internal/rename"
+)
+
+const (
+	serverName      = "namesprout"
+	generateTimeout = 45 * time.Second
+)
+
+type document struct {
+	text       string
+	version    int
+	languageID string
+}
+
+// suggestionCache 缓存某个文档版本中各位置的候选名称，文档变更或关闭时整体丢弃。
+type suggestionCache struct {
+	version int
+	names   map[string][]string
+}
+
+// checkedPackage 缓存 Go 文档某个版本所在包的类型检查结果（包括失败）。
+type checkedPackage struct {
+	version int
+	pkg     *rename.Package
+	err     error
+}
+
+// Server 通过 LSP 以代码操作的形式提供命名建议。
+type Server struct {
+	svc *app.Service
+
+	mu       sync.Mutex
+	docs     map[string]*document
+	cache    map[string]*suggestionCache
+	packages map[string]*checkedPackage
+	shutdown bool
+}
+
+// NewServer 构造 LSP 服务器。
+func NewServer(svc *app.Service) *Server {
+	return &Server{
+		svc:      svc,
+		docs:     make(map[string]*document),
+		cache:    make(map[string]*suggestionCache),
+		packages: make(map[string]*checkedPackage),
+	}
+}
+
+// Run 在给定的输入输出（通常为 stdio）上运行服务器，直至收到 exit 或输入结束。
+func (s *Server) Run(ctx context.Context, r io.Reader, w io.Writer) error {
+	conn := jsonrpc.NewConn(jsonrpc.NewHeaderStream(r, w))
+	return conn.Serve(ctx, s.handle)
+}
+
+func (s *Server) handle(ctx context.Context, conn *jsonrpc.Conn, msg *jsonrpc.Message) (any, error) {
+	s.mu.Lock()
+	closing := s.shutdown
+	s.mu.Unlock()
+	if closing && msg.Method != "exit" {
+		if msg.IsNotification() {
+			return nil, nil
+		}
+		return nil, jsonrpc.Errorf(jsonrpc.CodeInvalidRequest, "服务器正在关闭")
+	}
+
+	switch msg.Method {
+	case "initialize":
+		return map[string]any{
+			"capabilities": map[string]any{
+				"textDocumentSync": 1,
+				"codeActionProvider": map[string]any{
+					"codeActionKinds": []string{codeActionKindRefactor},
+				},
+			},
+			"serverInfo": map[string]string{"name": serverName},
+		}, nil
+	case "initialized", "$/setTrace", "workspace/didChangeConfiguration":
+		return nil, nil
+	case "$/cancelRequest":
+		var params cancelParams
+		if err := json.Unmarshal(msg.Params, &params); err != nil {
+			return nil, err
+		}
+		conn.Cancel(params.ID)
+		return nil, nil
+	case "shutdown":
+		s.mu.Lock()
+		s.shutdown = true
+		s.mu.Unlock()
+		return nil, nil
+	case "exit":
+		return nil, jsonrpc.ErrClosed
+	case "textDocument/didOpen":
+		var params didOpenParams
+		if err := json.Unmarshal(msg.Params, &params); err != nil {
+			return nil, err
+		}
+		s.mu.Lock()
+		s.docs[params.TextDocument.URI] = &document{
+			text:       params.TextDocument.Text,
+			version:    params.TextDocument.Version,
+			languageID: params.TextDocument.LanguageID,
+		}
+		s.mu.Unlock()
+		return nil, nil
+	case "textDocument/didChange":
+		var params didChangeParams
+		if err := json.Unmarshal(msg.Params, &params); err != nil {
+			return nil, err
+		}
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		delete(s.cache, params.TextDocument.URI)
+		doc, ok := s.docs[params.TextDocument.URI]
+		if !ok {
+			return nil, nil
+		}
+		for _, change := range params.ContentChanges {
+			if change.Range == nil {
+				doc.text = change.Text
+				continue
+			}
+			start, end := offsetOf(doc.text, change.Range.Start), offsetOf(doc.text, change.Range.End)
+			doc.text = doc.text[:start] + change.Text + doc.text[end:]
+		}
+		doc.version = params.TextDocument.Version
+		return nil, nil
+	case "textDocument/didClose":
+		var params didCloseParams
+		if err := json.Unmarshal(msg.Params, &params); err != nil {
+			return nil, err
+		}
+		s.mu.Lock()
+		delete(s.docs, params.TextDocument.URI)
+		delete(s.packages, params.TextDocument.URI)
+		delete(s.cache, params.TextDocument.URI)
+		s.mu.Unlock()
+		return nil, nil
+	case "textDocument/codeAction":
+		var params codeActionParams
+		if err := json.Unmarshal(msg.Params, &params); err != nil {
+			return nil, jsonrpc.Errorf(jsonrpc.CodeInvalidParams, "无效的参数: %v", err)
+		}
+		actions, err := s.codeActions(ctx, params)
+		if err != nil && errors.Is(ctx.Err(), context.Canceled) {
+			return nil, jsonrpc.Errorf(codeRequestCancelled, "请求已取消")
+		}
+		return actions, err
+	}
+
+	if msg.IsNotification() {
+		return nil, nil
+	}
+	return nil, jsonrpc.Errorf(jsonrpc.CodeMethodNotFound, "不支持的方法: %s", msg.Method)
+}
+
+func (s *Server) codeActions(ctx context.Context, params codeActionParams) ([]codeAction, error) {
+	actions := []codeAction{}
+	// 自动触发的请求非常频繁，只在用户主动请求代码操作时调用模型。
+	if params.Context.TriggerKind == codeActionTriggerAutomatic || !wantsRefactor(params.Context.Only) {
+		return actions, nil
+	}
+
+	uri := params.TextDocument.URI
+	s.mu.Lock()
+	doc, ok := s.docs[uri]
+	var text string
+	var version int
+	if ok {
+		text, version = doc.text, doc.version
+	}
+	s.mu.Unlock()
+	if !ok {
+		return actions, nil
+	}
+
+	offset := offsetOf(text, params.Range.Start)
+	symbol, start := identifierAt(text, offset)
+	if symbol == "" {
+		return actions, nil
+	}
+
+	path := uriToPath(uri)
+	line := params.Range.Start.Line + 1
+	kind := guessKind(text, start+len(symbol))
+
+	// 先查名称缓存，命中时无需再调用模型；类型检查结果按文档版本缓存，同一版本只检查一次。
+	key := fmt.Sprintf("%d:%s", line, symbol)
+	s.mu.Lock()
+	var (
+		names  []string
+		cached bool
+	)
+	if entry, ok := s.cache[uri]; ok && entry.version == version {
+		names, cached = entry.names[key]
+	}
+	s.mu.Unlock()
+
+	var target *rename.Target
+	if isGo(path, doc.languageID) {
+		if pkg, err := s.checkPackage(uri, version, path, text); err == nil {
+			column := start - strings.LastIndexByte(text[:start], '\n')
+			if located, err := pkg.Locate(line, column); err == nil {
+				target = located
+				kind = providers.NameKindVariable
+				if located.IsFunc() {
+					kind = providers.NameKindFunction
+				}
+			}
+		}
+	}
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	if !cached {
+		var err error
+		if names, err = s.suggest(ctx, symbol, kind, path, text, line); err != nil {
+			return nil, err
+		}
+		s.mu.Lock()
+		entry, ok := s.cache[uri]
+		if !ok || entry.version != version {
+			entry = &suggestionCache{version: version, names: make(map[string][]string)}
+			s.cache[uri] = entry
+		}
+		entry.names[key] = names
+		s.mu.Unlock()
+	}
+
+	for _, name := range names {
+		action := codeAction{
+			Title: fmt.Sprintf("Suggest names for %s: %s", symbol, name),
+			Kind:  codeActionKindRefactor,
+		}
+		if target != nil {
+			plan, err := target.Plan(name)
+			if err != nil {
+				action.Disabled = &struct {
+					Reason string `json:"reason"`
+				}{Reason: err.Error()}
+				actions = append(actions, action)
+				continue
+			}
+			action.Edit = planEdits(plan, uri, text)
+		} else {
+			action.Edit = textualEdits(uri, text, symbol, name, isGo(path, doc.languageID))
+		}
+		actions = append(actions, action)
+	}
+	return actions, nil
+}
+
+// checkPackage 返回 uri 所在包的类型检查结果，同一文档版本只检查一次。
+func (s *Server) checkPackage(uri string, version int, path, text string) (*rename.Package, error) {
+	s.mu.Lock()
+	checked, ok := s.packages[uri]
+	s.mu.Unlock()
+	if ok && checked.version == version {
+		return checked.pkg, checked.err
+	}
+
+	pkg, err := rename.Load(path, map[string][]byte{absPath(path): []byte(text)})
+	s.mu.Lock()
+	s.packages[uri] = &checkedPackage{version: version, pkg: pkg, err: err}
+	s.mu.Unlock()
+	return pkg, err
+}
+
+func (s *Server) suggest(ctx context.Context, symbol string, kind providers.NameKind, path, text string, line int) ([]string, error) {
+	description := fmt.Sprintf("为标识符 `%s` 重新命名。", symbol)
+	if snippet, err := codectx.ExtractSource(path, []byte(text), line); err == nil {
+		description = snippet.Description()
+		if snippet.Symbol != symbol {
+			description = fmt.Sprintf("%s\n\n需要重新命名的是第 %d 行中的标识符 `%s`。", description, line, symbol)
+		}
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, generateTimeout)
+	defer cancel()
+	result, err := s.svc.Suggest(ctx, app.SuggestOptions{
+		RequestOptions: app.RequestOptions{Description: description, Kind: kind},
+		Source:         "lsp",
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result.Names, nil
+}
+
+// planEdits 将类型检查后的重命名转换为工作区编辑。
+func planEdits(plan *rename.Plan, uri, text string) *workspaceEdit {
+	sources := make(map[string]string, len(plan.Files))
+	for _, change := range plan.Files {
+		sources[change.Path] = string(change.Before)
+	}
+
+	edit := &workspaceEdit{Changes: make(map[string][]textEdit)}
+	for _, e := range plan.Edits {
+		source := sources[e.Path]
+		target := pathToURI(e.Path)
+		if absPath(uriToPath(uri)) == e.Path {
+			target, source = uri, text
+		}
+		edit.Changes[target] = append(edit.Changes[target], textEdit{
+			Range:   textRange{Start: positionOf(source, e.Offset), End: positionOf(source, e.Offset+e.Length)},
+			NewText: plan.NewName,
+		})
+	}
+	return edit
+}
+
+// textualEdits 在无法做类型分析时，按整词替换当前文档中的出现位置。
+// Go 文件（语法错误导致类型检查失败时）跳过字符串、字符字面量与注释中的匹配；
+// 其它语言的字面量与注释语法各不相同，按纯文本替换，由用户在编辑器中预览确认。
+func textualEdits(uri, text, symbol, name string, goSource bool) *workspaceEdit {
+	pattern := regexp.MustCompile(`(^|[^\pL\pN_$])(` + regexp.QuoteMeta(symbol) + `)($|[^\pL\pN_$])`)
+	var skipped [][2]int
+	if goSource {
+		skipped = literalRanges(text)
+	}
+	var edits []textEdit
+	for _, match := range pattern.FindAllStringSubmatchIndex(text, -1) {
+		start, end := match[4], match[5]
+		if inRanges(skipped, start) {
+			continue
+		}
+		edits = append(edits, textEdit{
+			Range:   textRange{Start: positionOf(text, start), End: positionOf(text, end)},
+			NewText: name,
+		})
+	}
+	return &workspaceEdit{Changes: map[string][]textEdit{uri: edits}}
+}
+
+// literalRanges 用 go/scanner 切分 Go 源码，返回字符串、字符字面量与注释所占的字节区间（按起点升序）。
+func literalRanges(text string) [][2]int {
+	fset := token.NewFileSet()
+	file := fset.AddFile("", fset.Base(), len(text))
+	var sc scanner.Scanner
+	sc.Init(file, []byte(text), func(token.Position, string) {}, scanner.ScanComments)
+
+	var ranges [][2]int
+	for {
+		pos, tok, lit := sc.Scan()
+		if tok == token.EOF {
+			return ranges
+		}
+		switch tok {
+		case token.STRING, token.CHAR, token.COMMENT:
+			start := file.Offset(pos)
+			ranges = append(ranges, [2]int{start, start + len(lit)})
+		}
+	}
+}
+
+func inRanges(ranges [][2]int, offset int) bool {
+	i := sort.Search(len(ranges), func(i int) bool { return ranges[i][1] > offset })
+	return i < len(ranges) && ranges[i][0] <= offset
+}
+
+func wantsRefactor(only []string) bool {
+	if len(only) == 0 {
+		return true
+	}
+	for _, kind := range only {
+		if kind == codeActionKindRefactor || strings.HasPrefix(codeActionKindRefactor, kind+".") {
+			return true
+		}
+	}
+	return false
+}
+
+// identifierAt 返回 offset 处（或紧邻其左侧）的标识符及其起始偏移。
+func identifierAt(text string, offset int) (string, int) {
+	isIdent := func(r rune) bool { return r == '_' || r == '$' || unicode.IsLetter(r) || unicode.IsDigit(r) }
+	start, end := offset, offset
+	for start > 0 {
+		r := rune(text[start-1])
+		if r >= 0x80 || !isIdent(r) {
+			break
+		}
+		start--
+	}
+	for end < len(text) {
+		r := rune(text[end])
+		if r >= 0x80 || !isIdent(r) {
+			break
+		}
+		end++
+	}
+	word := text[start:end]
+	if word == "" || unicode.IsDigit(rune(word[0])) {
+		return "", 0
+	}
+	return word, start
+}
+
+// guessKind 以标识符后是否紧跟括号粗略判断函数与变量。
+func guessKind(text string, end int) providers.NameKind {
+	rest := strings.TrimLeft(text[end:], " \t")
+	if strings.HasPrefix(rest, "(") {
+		return providers.NameKindFunction
+	}
+	return providers.NameKindVariable
+}
+
+func isGo(path, languageID string) bool {
+	return languageID == "go" || strings.EqualFold(filepath.Ext(path), ".go")
+}
+
+func absPath(path string) string {
+	if abs, err := filepath.Abs(path); err == nil {
+		return abs
+	}
+	return path
+}
@@ -0,0 +1,240 @@
+package mcp
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/yanzzp/name-sprout/internal/app"
+	"github.com/yanzzp/name-sprout/internal/jsonrpc"
+	"github.com/yanzzp/name-sprout/internal/providers"
+)
+
+const (
+	serverName      = "namesprout"
+	protocolVersion = "2024-11-05"
+	generateTimeout = 60 * time.Second
+)
+
+// supportedVersions 为可协商的 MCP 协议版本，客户端请求其中之一时原样返回。
+var supportedVersions = map[string]bool{
+	"2024-11-05": true,
+	"2025-03-26": true,
+	"2025-06-18": true,
+}
+
+// Server 通过 MCP stdio 传输将命名能力暴露为工具。
+type Server struct {
+	svc *app.Service
+}
+
+// NewServer 构造 MCP 服务器。
+func NewServer(svc *app.Service) *Server {
+	return &Server{svc: svc}
+}
+
+// Run 在给定的输入输出（通常为 stdio）上运行服务器，直至输入结束。
+func (s *Server) Run(ctx context.Context, r io.Reader, w io.Writer) error {
+	conn := jsonrpc.NewConn(jsonrpc.NewLineStream(r, w))
+	return conn.Serve(ctx, s.handle)
+}
+
+type tool struct {
+	Name        string         `json:"name"`
+	Description string         `json:"description"`
+	InputSchema map[string]any `json:"inputSchema"`
+}
+
+type content struct {
+	Type string `json:"type"`
+	Text string `json:"text"`
+}
+
+type callResult struct {
+	Content           []content `json:"content"`
+	StructuredContent any       `json:"structuredContent,omitempty"`
+	IsError           bool      `json:"isError,omitempty"`
+}
+
+func (s *Server) handle(ctx context.Context, conn *jsonrpc.Conn, msg *jsonrpc.Message) (any, error) {
+	switch msg.Method {
+	case "initialize":
+		var params struct {
+			ProtocolVersion string `json:"protocolVersion"`
+		}
+		_ = json.Unmarshal(msg.Params, &params)
+		version := protocolVersion
+		if supportedVersions[params.ProtocolVersion] {
+			version = params.ProtocolVersion
+		}
+		return map[string]any{
+			"protocolVersion": version,
+			"capabilities": map[string]any{
+				"tools": map[string]any{"listChanged": false},
+			},
+			"serverInfo": map[string]string{"name": serverName, "version": "1.0.0"},
+		}, nil
+	case "ping":
+		return map[string]any{}, nil
+	case "tools/list":
+		return map[string]any{"tools": s.tools()}, nil
+	case "tools/call":
+		var params struct {
+			Name      string          `json:"name"`
+			Arguments json.RawMessage `json:"arguments"`
+		}
+		if err := json.Unmarshal(msg.Params, &params); err != nil {
+			return nil, jsonrpc.Errorf(jsonrpc.CodeInvalidParams, "无效的参数: %v", err)
+		}
+		return s.call(ctx, params.Name, params.Arguments)
+	}
+
+	if msg.IsNotification() {
+		return nil, nil
+	}
+	return nil, jsonrpc.Errorf(jsonrpc.CodeMethodNotFound, "不支持的方法: %s", msg.Method)
+}
+
+func (s *Server) tools() []tool {
+	lib := s.svc.Prompts()
+
+	kinds := make([]string, 0)
+	for _, kind := range lib.Kinds() {
+		kinds = append(kinds, string(kind))
+	}
+	styles := make([]string, 0)
+	for _, style := range lib.Styles() {
+		styles = append(styles, string(style))
+	}
+	empty := map[string]any{"type": "object", "properties": map[string]any{}}
+
+	return []tool{
+		{
+			Name:        "suggest_names",
+			Description: "根据描述生成符合团队命名规范与术语表的候选名称。",
+			InputSchema: map[string]any{
+				"type": "object",
+				"properties": map[string]any{
+					"description": map[string]any{"type": "string", "description": "待命名对象的用途或代码上下文"},
+					"kind":        map[string]any{"type": "string", "enum": kinds, "description": "命名类型"},
+					"style":       map[string]any{"type": "string", "description": fmt.Sprintf("命名格式，可选值：%s（也接受 list_styles 返回的别名），省略时使用命名类型的默认格式", strings.Join(styles, "、"))},
+					"count":       map[string]any{"type": "integer", "minimum": 1, "description": "候选数量，省略时使用配置的默认值"},
+				},
+				"required": []string{"description", "kind"},
+			},
+		},
+		{
+			Name:        "list_styles",
+			Description: "列出可用的命名格式及其别名。",
+			InputSchema: empty,
+		},
+		{
+			Name:        "list_kinds",
+			Description: "列出可用的命名类型及其默认命名格式。",
+			InputSchema: empty,
+		},
+	}
+}
+
+func (s *Server) call(ctx context.Context, name string, raw json.RawMessage) (*callResult, error) {
+	switch name {
+	case "suggest_names":
+		return s.suggestNames(ctx, raw), nil
+	case "list_styles":
+		lib := s.svc.Prompts()
+		type styleInfo struct {
+			ID      providers.NamingStyle `json:"id"`
+			Label   string                `json:"label"`
+			Aliases []string              `json:"aliases,omitempty"`
+		}
+		list := make([]styleInfo, 0)
+		for _, style := range lib.Styles() {
+			def, _ := lib.Definition(style)
+			list = append(list, styleInfo{ID: style, Label: def.Label, Aliases: def.Aliases})
+		}
+		return jsonResult(map[string]any{"styles": list}), nil
+	case "list_kinds":
+		lib := s.svc.Prompts()
+		type kindInfo struct {
+			ID           providers.NameKind    `json:"id"`
+			Label        string                `json:"label,omitempty"`
+			DefaultStyle providers.NamingStyle `json:"default_style,omitempty"`
+		}
+		list := make([]kindInfo, 0)
+		for _, kind := range lib.Kinds() {
+			def, _ := lib.KindDefinition(kind)
+			list = append(list, kindInfo{ID: kind, Label: def.Label, DefaultStyle: def.DefaultStyle})
+		}
+		return jsonResult(map[string]any{"kinds": list}), nil
+	}
+	return nil, jsonrpc.Errorf(jsonrpc.CodeInvalidParams, "未知的工具: %s", name)
+}
+
+func (s *Server) suggestNames(ctx context.Context, raw json.RawMessage) *callResult {
+	var args struct {
+		Description string `json:"description"`
+		Kind        string `json:"kind"`
+		Style       string `json:"style"`
+		Count       int    `json:"count"`
+	}
+	if len(raw) > 0 {
+		if err := json.Unmarshal(raw, &args); err != nil {
+			return errorResult(fmt.Errorf("无效的参数: %w", err))
+		}
+	}
+	if strings.TrimSpace(args.Description) == "" {
+		return errorResult(fmt.Errorf("description 不能为空"))
+	}
+	kind, err := providers.ParseNameKind(args.Kind)
+	if err != nil {
+		return errorResult(err)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, generateTimeout)
+	defer cancel()
+	result, err := s.svc.Suggest(ctx, app.SuggestOptions{RequestOptions: app.RequestOptions{
+		Description: args.Description,
+		Kind:        kind,
+		Style:       args.Style,
+		Count:       args.Count,
+	}, Source: "mcp"})
+	if err != nil {
+		return errorResult(err)
+	}
+
+	names := result.Names
+	if names == nil {
+		names = []string{}
+	}
+	return jsonResult(map[string]any{
+		"names":    names,
+		"dropped":  result.Dropped,
+		"kind":     result.Request.Kind,
+		"style":    result.Request.NamingStyle,
+		"provider": result.Provider,
+		"model":    result.Model,
+	})
+}
+
+// jsonResult 同时以文本和结构化内容返回结果，兼容不同版本的客户端。
+func jsonResult(v any) *callResult {
+	payload, err := json.Marshal(v)
+	if err != nil {
+		return errorResult(err)
+	}
+	return &callResult{
+		Content:           []content{{Type: "text", Text: string(payload)}},
+		StructuredContent: v,
+	}
+}
+
+// errorResult 以工具错误而非协议错误返回，便于模型读取原因并调整参数。
+func errorResult(err error) *callResult {
+	return &callResult{
+		Content: []content{{Type: "text", Text: err.Error()}},
+		IsError: true,
+	}
+}
@@ -6,9 +6,9 @@ import (
 	"strings"
 	"unicode"
 
-	"gopkg.in/yaml.v3"
-
 	"github.com/yanzzp/name-sprout/internal/providers"
+	"github.com/yanzzp/name-sprout/internal/strictyaml"
+	defaultprompts "github.com/yanzzp/name-sprout/prompts"
 )
 
 // NamingPromptDefinition 描述单个命名格式的提示词配置。
@@ -37,18 +37,88 @@ type NamingPrompts struct {
 	kindDefinitions map[providers.NameKind]KindPromptDefinition
 }
 
-// LoadNamingPrompts 从指定路径读取命名提示词配置。
+// LoadNamingPrompts 读取命名提示词配置：以内嵌的默认配置为基础，path 非空时用该文件覆盖其中的命名格式与命名类型。
+// 用户文件中的条目按字段合并，未填写的字段沿用内置值，aliases 去重后追加到内置别名之后。
+// 命名格式与命名类型的 key 必须是程序支持的取值，未知的 key 会报错。
 func LoadNamingPrompts(path string) (*NamingPrompts, error) {
-	raw, err := os.ReadFile(path)
+	file, err := parseNamingPromptFile(defaultprompts.Naming)
 	if err != nil {
-		return nil, fmt.Errorf("读取命名提示配置失败: %w", err)
+		return nil, fmt.Errorf("解析内置命名提示配置失败: %w", err)
 	}
 
+	if path != "" {
+		raw, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("读取命名提示配置失败: %w", err)
+		}
+		custom, err := parseNamingPromptFile(raw)
+		if err != nil {
+			return nil, fmt.Errorf("解析命名提示配置失败: %w", err)
+		}
+		file.overlay(custom)
+	}
+
+	return newNamingPrompts(file)
+}
+
+func parseNamingPromptFile(raw []byte) (namingPromptFile, error) {
 	var file namingPromptFile
-	if err := yaml.Unmarshal(raw, &file); err != nil {
-		return nil, fmt.Errorf("解析命名提示配置失败: %w", err)
+	err := strictyaml.Unmarshal(raw, &file)
+	return file, err
+}
+
+// overlay 将 top 中的条目合并到 f 上，key 是否受支持由 newNamingPrompts 校验。
+func (f *namingPromptFile) overlay(top namingPromptFile) {
+	if f.Styles == nil {
+		f.Styles = make(map[string]NamingPromptDefinition)
+	}
+	for key, def := range top.Styles {
+		base := f.Styles[key]
+		if def.Label != "" {
+			base.Label = def.Label
+		}
+		if def.Prompt != "" {
+			base.Prompt = def.Prompt
+		}
+		base.Aliases = mergeAliases(base.Aliases, def.Aliases)
+		f.Styles[key] = base
 	}
 
+	if f.Kinds == nil {
+		f.Kinds = make(map[string]KindPromptDefinition)
+	}
+	for key, def := range top.Kinds {
+		base := f.Kinds[key]
+		if def.Label != "" {
+			base.Label = def.Label
+		}
+		if def.Prompt != "" {
+			base.Prompt = def.Prompt
+		}
+		if def.DefaultStyle != "" {
+			base.DefaultStyle = def.DefaultStyle
+		}
+		f.Kinds[key] = base
+	}
+}
+
+// mergeAliases 将 extra 中尚未出现的别名追加到 base 之后，比较时忽略大小写与首尾空白。
+// init 写出的命名提示文件是内置配置的完整副本，不去重会让每个别名都出现两次。
+func mergeAliases(base, extra []string) []string {
+	seen := make(map[string]bool, len(base)+len(extra))
+	merged := make([]string, 0, len(base)+len(extra))
+	for _, alias := range append(append([]string(nil), base...), extra...) {
+		key := strings.ToLower(strings.TrimSpace(alias))
+		if seen[key] {
+			continue
+		}
+		seen[key] = true
+		merged = append(merged, alias)
+	}
+	return merged
+}
+
+func newNamingPrompts(file namingPromptFile) (*NamingPrompts, error) {
 	if len(file.Styles) == 0 {
 		return nil, fmt.Errorf("命名提示配置为空")
 	}
@@ -111,6 +181,22 @@ func (n *NamingPrompts) KindDefinition(kind providers.NameKind) (KindPromptDefin
 	return def, ok
 }
 
+// Styles 按 providers.AllNamingStyles 的顺序返回已配置的命名格式。
+func (n *NamingPrompts) Styles() []providers.NamingStyle {
+	styles := make([]providers.NamingStyle, 0, len(n.definitions))
+	for _, style := range providers.AllNamingStyles {
+		if _, ok := n.definitions[style]; ok {
+			styles = append(styles, style)
+		}
+	}
+	return styles
+}
+
+// Kinds 按 providers.AllNameKinds 的顺序返回全部命名类型，未配置提示的类型同样包含在内。
+func (n *NamingPrompts) Kinds() []providers.NameKind {
+	return append([]providers.NameKind(nil), providers.AllNameKinds...)
+}
+
 // Lookup 根据别名或关键字查找命名格式定义。
 func (n *NamingPrompts) Lookup(raw string) (providers.NamingStyle, NamingPromptDefinition, bool) {
 	style, ok := n.aliases[normalizeAlias(raw)]
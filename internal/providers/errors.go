@@ -0,0 +1,111 @@
+package providers
+
+import (
+	"context"
+	"errors"
+	"net"
+)
+
+// ErrorClass 是对调用失败原因的粗粒度分类，便于给出排查建议。
+type ErrorClass string
+
+const (
+	ErrorClassConfig   ErrorClass = "config"
+	ErrorClassAuth     ErrorClass = "auth"
+	ErrorClassQuota    ErrorClass = "quota"
+	ErrorClassModel    ErrorClass = "model"
+	ErrorClassNetwork  ErrorClass = "network"
+	ErrorClassTimeout  ErrorClass = "timeout"
+	ErrorClassServer   ErrorClass = "server"
+	ErrorClassResponse ErrorClass = "response"
+	ErrorClassUnknown  ErrorClass = "unknown"
+)
+
+// Label 返回分类的中文名称。
+func (c ErrorClass) Label() string {
+	switch c {
+	case ErrorClassConfig:
+		return "配置错误"
+	case ErrorClassAuth:
+		return "认证失败"
+	case ErrorClassQuota:
+		return "配额或限流"
+	case ErrorClassModel:
+		return "模型不可用"
+	case ErrorClassNetwork:
+		return "网络错误"
+	case ErrorClassTimeout:
+		return "请求超时"
+	case ErrorClassServer:
+		return "服务端错误"
+	case ErrorClassResponse:
+		return "响应异常"
+	default:
+		return "未知错误"
+	}
+}
+
+// Hint 返回该类错误的排查建议。
+func (c ErrorClass) Hint() string {
+	switch c {
+	case ErrorClassConfig:
+		return "检查配置文件中该提供方的字段，可运行 namesprout config validate"
+	case ErrorClassAuth:
+		return "检查 api_key 或云凭据是否正确、对应的环境变量或密钥命令是否生效"
+	case ErrorClassQuota:
+		return "请求过于频繁或额度已用尽，稍后重试或更换 API Key"
+	case ErrorClassModel:
+		return "检查 model 是否拼写正确、当前 API Key 是否有权访问该模型"
+	case ErrorClassNetwork:
+		return "检查网络连通性与 proxy 设置"
+	case ErrorClassTimeout:
+		return "网络较慢或代理不可达，可检查 proxy 设置后重试"
+	case ErrorClassServer:
+		return "提供方服务暂时异常，稍后重试"
+	case ErrorClassResponse:
+		return "模型返回了无法解析的结果，可尝试调整 temperature 或更换模型"
+	default:
+		return ""
+	}
+}
+
+// ErrorClassifier 可选接口，允许 Provider 结合自身 SDK 的错误类型进行分类。
+type ErrorClassifier interface {
+	ClassifyError(err error) ErrorClass
+}
+
+// ClassifyError 对与提供方无关的错误（超时、网络）进行分类，无法识别时返回 ErrorClassUnknown。
+func ClassifyError(err error) ErrorClass {
+	var netErr net.Error
+	switch {
+	case err == nil:
+		return ""
+	case errors.Is(err, context.DeadlineExceeded):
+		return ErrorClassTimeout
+	case errors.As(err, &netErr):
+		if netErr.Timeout() {
+			return ErrorClassTimeout
+		}
+		return ErrorClassNetwork
+	default:
+		return ErrorClassUnknown
+	}
+}
+
+// ClassifyStatus 按 HTTP 状态码分类，供各 Provider 实现 ErrorClassifier 时复用。
+func ClassifyStatus(code int) ErrorClass {
+	switch {
+	case code == 400:
+		return ErrorClassConfig
+	case code == 401 || code == 403:
+		return ErrorClassAuth
+	case code == 404:
+		return ErrorClassModel
+	case code == 429:
+		return ErrorClassQuota
+	case code >= 500:
+		return ErrorClassServer
+	default:
+		return ErrorClassUnknown
+	}
+}
@@ -0,0 +1,34 @@
+package gemini
+
+import (
+	"errors"
+	"strings"
+
+	"google.golang.org/genai"
+
+	"github.com/yanzzp/name-sprout/internal/providers"
+)
+
+var (
+	errEmptyResponse = errors.New("Gemini 返回结果为空")
+	errBlocked       = errors.New("Gemini 拒绝了请求")
+)
+
+// ClassifyError 实现 providers.ErrorClassifier，识别 Gemini API 返回的状态码与错误原因。
+func (p *geminiProvider) ClassifyError(err error) providers.ErrorClass {
+	var apiErr genai.APIError
+	switch {
+	case errors.As(err, &apiErr):
+		// 无效的 API Key 以 400 INVALID_ARGUMENT 返回，需要根据错误原因单独识别。
+		if strings.Contains(apiErr.Message, "API_KEY_INVALID") || strings.Contains(apiErr.Message, "API key not valid") {
+			return providers.ErrorClassAuth
+		}
+		return providers.ClassifyStatus(apiErr.Code)
+	case errors.Is(err, errCredentials):
+		return providers.ErrorClassAuth
+	case errors.Is(err, errEmptyResponse), errors.Is(err, errBlocked):
+		return providers.ErrorClassResponse
+	default:
+		return providers.ClassifyError(err)
+	}
+}
@@ -0,0 +1,50 @@
+package gemini
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+
+	"google.golang.org/genai"
+
+	"github.com/yanzzp/name-sprout/internal/providers"
+)
+
+// generateAction 为支持内容生成的模型在 SupportedActions 中声明的动作。
+const generateAction = "generateContent"
+
+// ListModels 实现 providers.ModelLister，只返回支持 generateContent 的模型，按 ID 排序。
+func (p *geminiProvider) ListModels(ctx context.Context) ([]providers.ModelInfo, error) {
+	if err := p.ensureClient(ctx); err != nil {
+		return nil, err
+	}
+
+	var models []providers.ModelInfo
+	for model, err := range p.client.Models.All(ctx) {
+		if err != nil {
+			return nil, fmt.Errorf("获取 Gemini 模型列表失败: %w", err)
+		}
+		if model == nil || !p.canGenerate(model) {
+			continue
+		}
+		models = append(models, providers.ModelInfo{
+			ID:               model.Name,
+			DisplayName:      model.DisplayName,
+			Description:      model.Description,
+			InputTokenLimit:  int(model.InputTokenLimit),
+			OutputTokenLimit: int(model.OutputTokenLimit),
+		})
+	}
+	sort.Slice(models, func(i, j int) bool { return models[i].ID < models[j].ID })
+	return models, nil
+}
+
+// canGenerate 判断模型是否支持内容生成。Vertex AI 返回的发布方模型不携带 SupportedActions，按名称识别 Gemini 模型。
+func (p *geminiProvider) canGenerate(model *genai.Model) bool {
+	if len(model.SupportedActions) > 0 {
+		return slices.Contains(model.SupportedActions, generateAction)
+	}
+	return p.vertex != nil && strings.Contains(model.Name, "gemini")
+}
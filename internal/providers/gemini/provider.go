@@ -7,6 +7,7 @@ import (
 	"fmt"
 	"net/http"
 	"net/url"
+	"os"
 	"strings"
 	"sync"
 
@@ -18,10 +19,13 @@ import (
 
 const (
 	providerType               = "gemini"
-	defaultModel               = "models/gemini-1.5-pro"
+	defaultModel               = "models/gemini-2.5-flash"
 	defaultTemperature float32 = 0.7
 )
 
+// apiKeyEnvs 为未配置 api_key 时依次读取的环境变量。
+var apiKeyEnvs = []string{"GEMINI_API_KEY", "GOOGLE_API_KEY"}
+
 type geminiProvider struct {
 	name        string
 	model       string
@@ -32,6 +36,8 @@ type geminiProvider struct {
 	temperature float32
 	topK        *float32
 	httpClient  *http.Client
+	// vertex 非空时通过 Vertex AI 后端访问 Gemini。
+	vertex *vertexConfig
 }
 
 // Register Gemini provider when package initializes.
@@ -40,13 +46,36 @@ func init() {
 }
 
 func newGeminiProvider(name string, settings config.ProviderSettings) (providers.Provider, error) {
-	if settings.APIKey == "" {
-		return nil, errors.New("Gemini 配置缺少 api_key")
+	vertex, err := parseVertexOptions(settings.Options)
+	if err != nil {
+		return nil, err
+	}
+
+	apiKey := settings.APIKey
+	if vertex != nil {
+		if apiKey != "" {
+			return nil, errors.New("Vertex AI 后端通过 Google Cloud 凭据认证，不能同时配置 api_key")
+		}
+	} else {
+		for _, env := range apiKeyEnvs {
+			if apiKey != "" {
+				break
+			}
+			apiKey = os.Getenv(env)
+		}
+		if apiKey == "" {
+			return nil, errors.New("Gemini 配置缺少 api_key，且未设置 GEMINI_API_KEY / GOOGLE_API_KEY 环境变量")
+		}
 	}
+
 	model := settings.Model
 	if model == "" {
 		model = defaultModel
 	}
+	if vertex != nil {
+		// Vertex AI 的模型位于 publishers/google/models 下，去掉 Gemini API 风格的前缀后由 SDK 补全。
+		model = strings.TrimPrefix(model, "models/")
+	}
 
 	temperature := defaultTemperature
 	if settings.Temperature != nil {
@@ -70,10 +99,11 @@ func newGeminiProvider(name string, settings config.ProviderSettings) (providers
 	return &geminiProvider{
 		name:        name,
 		model:       model,
-		apiKey:      settings.APIKey,
+		apiKey:      apiKey,
 		temperature: temperature,
 		topK:        topK,
 		httpClient:  httpClient,
+		vertex:      vertex,
 	}, nil
 }
 
@@ -86,36 +116,21 @@ func (p *geminiProvider) GenerateNames(ctx context.Context, req providers.Reques
 		return nil, err
 	}
 
-	count := req.Count
-	if count <= 0 {
-		count = 5
-	}
-	if count > 12 {
-		count = 12
-	}
-
+	count := clampCount(req.Count)
 	prompt := buildPrompt(req, count)
 
-	config := &genai.GenerateContentConfig{
-		Temperature:      genai.Ptr[float32](p.temperature),
-		ResponseMIMEType: "application/json",
-	}
-	if p.topK != nil {
-		config.TopK = p.topK
-	}
-
-	resp, err := p.client.Models.GenerateContent(ctx, p.model, genai.Text(prompt), config)
+	resp, err := p.client.Models.GenerateContent(ctx, p.model, genai.Text(prompt), p.contentConfig())
 	if err != nil {
 		return nil, fmt.Errorf("调用 Gemini API 失败: %w", err)
 	}
 
 	if resp.PromptFeedback != nil && resp.PromptFeedback.BlockReason != "" {
-		return nil, fmt.Errorf("Gemini 拒绝了请求: %s", resp.PromptFeedback.BlockReason)
+		return nil, fmt.Errorf("%w: %s", errBlocked, resp.PromptFeedback.BlockReason)
 	}
 
 	text := collectText(resp)
 	if text == "" {
-		return nil, errors.New("Gemini 返回结果为空")
+		return nil, errEmptyResponse
 	}
 
 	names, err := parseNamesFromJSON(text)
@@ -123,17 +138,64 @@ func (p *geminiProvider) GenerateNames(ctx context.Context, req providers.Reques
 		// Fallback: 尝试基于换行分割
 		return fallbackNames(text, count), nil
 	}
+	// 模型偶尔会多给几个，截断到请求的数量。
+	if len(names) > count {
+		names = names[:count]
+	}
 
 	return names, nil
 }
 
+// maxCount 为单次请求最多生成的名称数量，更多的候选会明显拖慢响应且重复率上升。
+const maxCount = 12
+
+// clampCount 将请求的名称数量限制在 1–maxCount 之间，未指定时为 5。
+func clampCount(count int) int {
+	if count <= 0 {
+		return 5
+	}
+	if count > maxCount {
+		return maxCount
+	}
+	return count
+}
+
+// MaxCount 实现 providers.CountLimiter。
+func (p *geminiProvider) MaxCount() int {
+	return maxCount
+}
+
+func (p *geminiProvider) contentConfig() *genai.GenerateContentConfig {
+	config := &genai.GenerateContentConfig{
+		Temperature:      genai.Ptr[float32](p.temperature),
+		ResponseMIMEType: "application/json",
+	}
+	if p.topK != nil {
+		config.TopK = p.topK
+	}
+	return config
+}
+
 func (p *geminiProvider) ensureClient(ctx context.Context) error {
 	p.once.Do(func() {
 		config := &genai.ClientConfig{
 			APIKey:  p.apiKey,
 			Backend: genai.BackendGeminiAPI,
 		}
-		if p.httpClient != nil {
+		if p.vertex != nil {
+			httpClient, creds, err := p.vertex.authorize(ctx, p.httpClient)
+			if err != nil {
+				p.init = err
+				return
+			}
+			config = &genai.ClientConfig{
+				Backend:     genai.BackendVertexAI,
+				Project:     p.vertex.project,
+				Location:    p.vertex.location,
+				Credentials: creds,
+				HTTPClient:  httpClient,
+			}
+		} else if p.httpClient != nil {
 			config.HTTPClient = p.httpClient
 		}
 		client, err := genai.NewClient(ctx, config)
@@ -193,6 +255,11 @@ func buildPrompt(req providers.Request, count int) string {
 		b.WriteString(prompt)
 		b.WriteString("\n")
 	}
+	if prompt := strings.TrimSpace(req.GlossaryPrompt); prompt != "" {
+		b.WriteString("\n术语表要求：\n")
+		b.WriteString(prompt)
+		b.WriteString("\n")
+	}
 	b.WriteString("\n请直接返回 JSON，对名称进行去重，并确保每个名称不超过 32 个字符。")
 	return b.String()
 }
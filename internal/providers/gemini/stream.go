@@ -0,0 +1,171 @@
+package gemini
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"google.golang.org/genai"
+
+	"github.com/yanzzp/name-sprout/internal/providers"
+)
+
+// StreamNames 通过 GenerateContentStream 生成名称，JSON 数组中的字符串一旦完整就立即返回。
+func (p *geminiProvider) StreamNames(ctx context.Context, req providers.Request) (<-chan providers.Candidate, error) {
+	if err := p.ensureClient(ctx); err != nil {
+		return nil, err
+	}
+
+	count := clampCount(req.Count)
+	prompt := buildPrompt(req, count)
+
+	out := make(chan providers.Candidate)
+	go func() {
+		defer close(out)
+
+		send := func(candidate providers.Candidate) bool {
+			select {
+			case out <- candidate:
+				return true
+			case <-ctx.Done():
+				return false
+			}
+		}
+
+		var (
+			scanner nameScanner
+			text    strings.Builder
+			seen    = make(map[string]bool)
+		)
+		// emit 达到请求数量后返回 false，停止接收剩余的流，多出的名称直接丢弃。
+		emit := func(names []string) bool {
+			for _, name := range names {
+				if len(seen) >= count {
+					return false
+				}
+				name = strings.TrimSpace(name)
+				if name == "" || seen[name] {
+					continue
+				}
+				seen[name] = true
+				if !send(providers.Candidate{Name: name}) {
+					return false
+				}
+			}
+			return true
+		}
+
+		for resp, err := range p.client.Models.GenerateContentStream(ctx, p.model, genai.Text(prompt), p.contentConfig()) {
+			if err != nil {
+				send(providers.Candidate{Err: fmt.Errorf("调用 Gemini API 失败: %w", err)})
+				return
+			}
+			if resp.PromptFeedback != nil && resp.PromptFeedback.BlockReason != "" {
+				send(providers.Candidate{Err: fmt.Errorf("%w: %s", errBlocked, resp.PromptFeedback.BlockReason)})
+				return
+			}
+			chunk := chunkText(resp)
+			text.WriteString(chunk)
+			if !emit(scanner.Feed(chunk)) {
+				return
+			}
+		}
+
+		if len(seen) > 0 {
+			return
+		}
+		// 流中没有解析出 JSON 数组时，按完整文本回退解析。
+		raw := strings.TrimSpace(text.String())
+		if raw == "" {
+			send(providers.Candidate{Err: errEmptyResponse})
+			return
+		}
+		names, err := parseNamesFromJSON(raw)
+		if err != nil {
+			names = fallbackNames(raw, count)
+		}
+		emit(names)
+	}()
+	return out, nil
+}
+
+// chunkText 拼接流式响应片段中的文本，不做裁剪，以免破坏跨片段的 JSON。
+func chunkText(resp *genai.GenerateContentResponse) string {
+	if resp == nil {
+		return ""
+	}
+	var sb strings.Builder
+	for _, cand := range resp.Candidates {
+		if cand == nil || cand.Content == nil {
+			continue
+		}
+		for _, part := range cand.Content.Parts {
+			if part == nil || part.Thought {
+				continue
+			}
+			sb.WriteString(part.Text)
+		}
+	}
+	return sb.String()
+}
+
+// nameScanner 从逐段到达的 JSON 文本中提取第一个数组里已经完整的字符串，
+// 同时兼容 {"names": [...]} 与直接返回数组两种形式。
+type nameScanner struct {
+	buf     []byte
+	pos     int
+	inArray bool
+	done    bool
+}
+
+// Feed 追加一段文本并返回新出现的完整名称。
+func (s *nameScanner) Feed(chunk string) []string {
+	s.buf = append(s.buf, chunk...)
+
+	var names []string
+	for !s.done && s.pos < len(s.buf) {
+		if !s.inArray {
+			idx := bytes.IndexByte(s.buf[s.pos:], '[')
+			if idx < 0 {
+				s.pos = len(s.buf)
+				break
+			}
+			s.pos += idx + 1
+			s.inArray = true
+			continue
+		}
+
+		switch s.buf[s.pos] {
+		case ']':
+			s.done = true
+		case '"':
+			end := closingQuote(s.buf, s.pos+1)
+			if end < 0 {
+				// 字符串尚未完整，等待下一段。
+				return names
+			}
+			var name string
+			if err := json.Unmarshal(s.buf[s.pos:end+1], &name); err == nil {
+				names = append(names, name)
+			}
+			s.pos = end + 1
+			continue
+		}
+		s.pos++
+	}
+	return names
+}
+
+func closingQuote(buf []byte, from int) int {
+	for i := from; i < len(buf); i++ {
+		switch buf[i] {
+		case '\\':
+			i++
+		case '"':
+			return i
+		}
+	}
+	return -1
+}
@@ -0,0 +1,85 @@
+package gemini
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+
+	"cloud.google.com/go/auth"
+	"cloud.google.com/go/auth/credentials"
+	"cloud.google.com/go/auth/httptransport"
+)
+
+const (
+	cloudPlatformScope = "https://www.googleapis.com/auth/cloud-platform"
+	// defaultVertexLocation 为未配置 location 时使用的区域，global 端点可访问全部 Gemini 模型。
+	defaultVertexLocation = "global"
+)
+
+var errCredentials = errors.New("获取 Google Cloud 凭据失败")
+
+// vertexConfig 为 Vertex AI 后端的连接参数，来自 ProviderSettings.Options。
+type vertexConfig struct {
+	project  string
+	location string
+	// credentialsFile 为服务账号 JSON 路径，为空时使用应用默认凭据（ADC）。
+	credentialsFile string
+}
+
+// parseVertexOptions 读取 options.backend，选择 Vertex AI 时返回其连接参数，使用 Gemini API 时返回 nil。
+// project 与 location 未配置时依次回退到 GOOGLE_CLOUD_PROJECT、GOOGLE_CLOUD_LOCATION 环境变量。
+func parseVertexOptions(options map[string]string) (*vertexConfig, error) {
+	switch strings.ToLower(strings.TrimSpace(options["backend"])) {
+	case "", "gemini", "gemini_api":
+		return nil, nil
+	case "vertex", "vertexai", "vertex_ai":
+	default:
+		return nil, fmt.Errorf("Gemini 配置的 options.backend 无效: %s（可选 gemini / vertex）", options["backend"])
+	}
+
+	cfg := &vertexConfig{
+		project:         firstNonEmpty(options["project"], os.Getenv("GOOGLE_CLOUD_PROJECT")),
+		location:        firstNonEmpty(options["location"], os.Getenv("GOOGLE_CLOUD_LOCATION"), defaultVertexLocation),
+		credentialsFile: options["credentials_file"],
+	}
+	if cfg.project == "" {
+		return nil, errors.New("Vertex AI 后端缺少 options.project，且未设置 GOOGLE_CLOUD_PROJECT 环境变量")
+	}
+	return cfg, nil
+}
+
+// authorize 加载 Google Cloud 凭据并返回携带认证信息的 HTTP 客户端，base 非空时沿用其代理设置。
+func (v *vertexConfig) authorize(ctx context.Context, base *http.Client) (*http.Client, *auth.Credentials, error) {
+	creds, err := credentials.DetectDefault(&credentials.DetectOptions{
+		Scopes:          []string{cloudPlatformScope},
+		CredentialsFile: v.credentialsFile,
+	})
+	if err != nil {
+		return nil, nil, fmt.Errorf("%w: %w", errCredentials, err)
+	}
+
+	opts := &httptransport.Options{Credentials: creds}
+	if quotaProject, err := creds.QuotaProjectID(ctx); err == nil && quotaProject != "" {
+		opts.Headers = http.Header{"X-Goog-User-Project": []string{quotaProject}}
+	}
+	if base != nil {
+		opts.BaseRoundTripper = base.Transport
+	}
+	client, err := httptransport.NewClient(opts)
+	if err != nil {
+		return nil, nil, fmt.Errorf("%w: %w", errCredentials, err)
+	}
+	return client, creds, nil
+}
+
+func firstNonEmpty(values ...string) string {
+	for _, value := range values {
+		if value = strings.TrimSpace(value); value != "" {
+			return value
+		}
+	}
+	return ""
+}
@@ -73,6 +73,7 @@ type Request struct {
 	NamingStyle       NamingStyle
 	NamingStyleLabel  string
 	NamingStylePrompt string
+	GlossaryPrompt    string
 }
 
 // Provider 定义不同模型提供方需要实现的接口。
@@ -86,7 +87,38 @@ type ModelReporter interface {
 	ModelIdentifier() string
 }
 
+// ModelInfo 描述提供方可用的一个模型。
+type ModelInfo struct {
+	ID               string `json:"id"`
+	DisplayName      string `json:"display_name,omitempty"`
+	Description      string `json:"description,omitempty"`
+	InputTokenLimit  int    `json:"input_token_limit,omitempty"`
+	OutputTokenLimit int    `json:"output_token_limit,omitempty"`
+}
+
+// ModelLister 可选接口，允许 Provider 列出当前凭据可用于生成名称的模型。
+type ModelLister interface {
+	ListModels(ctx context.Context) ([]ModelInfo, error)
+}
+
+// CountLimiter 可选接口，报告 Provider 单次最多生成的名称数量，超出部分的请求会被截断。
+type CountLimiter interface {
+	MaxCount() int
+}
+
 // Initializer 用于声明 Provider 支持启动前的健康检查。
 type Initializer interface {
 	Warmup(ctx context.Context) error
 }
+
+// Candidate 是流式生成中逐个返回的名称，Err 非空表示生成中途失败。
+type Candidate struct {
+	Name string
+	Err  error
+}
+
+// StreamingProvider 可选接口，允许 Provider 在生成过程中逐个返回名称。
+// 返回的通道在生成结束、出错或 ctx 取消后关闭，出错时最后一项携带 Err。
+type StreamingProvider interface {
+	StreamNames(ctx context.Context, req Request) (<-chan Candidate, error)
+}
@@ -0,0 +1,43 @@
+package rename
+
+import (
+	"bytes"
+	"go/build"
+	"io"
+	"path/filepath"
+)
+
+// knownOS 与 knownArch 为目标文件不适用于当前平台时尝试的构建目标，顺序即优先级。
+var (
+	knownOS   = []string{"linux", "darwin", "windows", "freebsd", "openbsd", "netbsd", "dragonfly", "solaris", "illumos", "aix", "android", "ios", "plan9", "js", "wasip1"}
+	knownArch = []string{"amd64", "arm64", "386", "arm", "wasm", "riscv64", "ppc64le", "s390x", "mips64le", "loong64"}
+)
+
+// buildContext 返回用于筛选同包文件的构建上下文，文件内容经 readFile 读取以支持 overlay。
+// 优先使用当前平台；目标文件带有其它平台的构建约束（如 _windows.go、//go:build darwin）时，
+// 改用第一个能匹配该文件的 GOOS/GOARCH，使同一平台的其它文件一起参与类型检查。
+func buildContext(path string, readFile func(string) ([]byte, error)) build.Context {
+	ctxt := build.Default
+	ctxt.OpenFile = func(name string) (io.ReadCloser, error) {
+		src, err := readFile(name)
+		if err != nil {
+			return nil, err
+		}
+		return io.NopCloser(bytes.NewReader(src)), nil
+	}
+
+	dir, name := filepath.Split(path)
+	if ok, err := ctxt.MatchFile(dir, name); err != nil || ok {
+		return ctxt
+	}
+	for _, goos := range append([]string{ctxt.GOOS}, knownOS...) {
+		for _, goarch := range append([]string{ctxt.GOARCH}, knownArch...) {
+			candidate := ctxt
+			candidate.GOOS, candidate.GOARCH = goos, goarch
+			if ok, err := candidate.MatchFile(dir, name); err == nil && ok {
+				return candidate
+			}
+		}
+	}
+	return ctxt
+}
@@ -0,0 +1,53 @@
+package rename
+
+import (
+	"fmt"
+	"strings"
+)
+
+const diffContext = 3
+
+// unifiedDiff 生成统一 diff。重命名只替换标识符、不增删行，
+// 因此前后内容逐行对齐，无需通用的 LCS 算法。
+func unifiedDiff(path string, before, after []byte) string {
+	oldLines := strings.Split(string(before), "\n")
+	newLines := strings.Split(string(after), "\n")
+	if len(oldLines) != len(newLines) {
+		return fmt.Sprintf("--- %s\n+++ %s\n@@ 行数发生变化，无法生成预览 @@\n", path, path)
+	}
+
+	var changed []int
+	for i := range oldLines {
+		if oldLines[i] != newLines[i] {
+			changed = append(changed, i)
+		}
+	}
+	if len(changed) == 0 {
+		return ""
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "--- %s\n+++ %s\n", path, path)
+	for i := 0; i < len(changed); {
+		start := max(changed[i]-diffContext, 0)
+		end := changed[i]
+		j := i
+		for j+1 < len(changed) && changed[j+1]-end <= 2*diffContext {
+			j++
+			end = changed[j]
+		}
+		end = min(end+diffContext, len(oldLines)-1)
+
+		count := end - start + 1
+		fmt.Fprintf(&b, "@@ -%d,%d +%d,%d @@\n", start+1, count, start+1, count)
+		for k := start; k <= end; k++ {
+			if oldLines[k] == newLines[k] {
+				fmt.Fprintf(&b, " %s\n", oldLines[k])
+				continue
+			}
+			fmt.Fprintf(&b, "-%s\n+%s\n", oldLines[k], newLines[k])
+		}
+		i = j + 1
+	}
+	return b.String()
+}
@@ -0,0 +1,432 @@
+package rename
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/importer"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// FileChange 记录单个文件重命名前后的内容。
+type FileChange struct {
+	Path   string
+	Before []byte
+	After  []byte
+}
+
+// Plan 描述一次经过类型检查的重命名，Apply 之前不会修改任何文件。
+type Plan struct {
+	OldName    string
+	NewName    string
+	References int
+	Files      []FileChange
+	// Edits 为未经 gofmt 的逐处替换，按文件分组、组内偏移从大到小排列。
+	Edits    []Edit
+	Warnings []string
+}
+
+// Edit 记录单处标识符替换，Offset 为原文件中的字节偏移。
+type Edit struct {
+	Path   string
+	Offset int
+	Length int
+}
+
+// Target 是已完成类型检查并定位到的待重命名标识符，可对多个新名称复用。
+type Target struct {
+	pkg  *loadedPackage
+	obj  types.Object
+	refs []*ast.Ident
+}
+
+// Prepare 定位 path 中 line:column 处的标识符，并在其所属包内规划重命名。
+// column 为 1 起始的字节列号，与 go/token 的约定一致。
+func Prepare(path string, line, column int, newName string) (*Plan, error) {
+	target, err := Locate(path, line, column, nil)
+	if err != nil {
+		return nil, err
+	}
+	return target.Plan(newName)
+}
+
+// Locate 对 path 所在的包做类型检查并定位 line:column 处的标识符。
+// overlay 以绝对路径为 key，用于替代磁盘上的文件内容（例如编辑器中未保存的缓冲区）。
+func Locate(path string, line, column int, overlay map[string][]byte) (*Target, error) {
+	pkg, err := Load(path, overlay)
+	if err != nil {
+		return nil, err
+	}
+	return pkg.Locate(line, column)
+}
+
+// Package 是 path 所在包的类型检查结果，可在同一份源码上多次定位标识符，避免重复类型检查。
+type Package struct {
+	path string
+	pkg  *loadedPackage
+}
+
+// Load 对 path 所在的包做类型检查，overlay 的含义与 Locate 相同。
+func Load(path string, overlay map[string][]byte) (*Package, error) {
+	abs, err := filepath.Abs(path)
+	if err != nil {
+		return nil, fmt.Errorf("解析文件路径失败: %w", err)
+	}
+
+	pkg, err := loadPackage(abs, overlay)
+	if err != nil {
+		return nil, err
+	}
+	return &Package{path: abs, pkg: pkg}, nil
+}
+
+// Locate 定位 Load 时指定的文件中 line:column 处的标识符。
+func (p *Package) Locate(line, column int) (*Target, error) {
+	pkg := p.pkg
+	ident := pkg.identAt(p.path, line, column)
+	if ident == nil {
+		return nil, fmt.Errorf("%s:%d:%d 处没有标识符", p.path, line, column)
+	}
+	obj := pkg.info.ObjectOf(ident)
+	if obj == nil {
+		return nil, fmt.Errorf("无法解析标识符 %s 的类型信息", ident.Name)
+	}
+	if obj.Pkg() != pkg.types {
+		return nil, fmt.Errorf("%s 定义在其它包中，无法在当前包内重命名", obj.Name())
+	}
+	return &Target{pkg: pkg, obj: obj, refs: pkg.references(obj)}, nil
+}
+
+// Name 返回标识符当前的名称。
+func (t *Target) Name() string {
+	return t.obj.Name()
+}
+
+// IsFunc 判断标识符是否为函数或方法。
+func (t *Target) IsFunc() bool {
+	_, ok := t.obj.(*types.Func)
+	return ok
+}
+
+// Plan 规划将标识符重命名为 newName 所需的改动并检查冲突。
+func (t *Target) Plan(newName string) (*Plan, error) {
+	if !token.IsIdentifier(newName) {
+		return nil, fmt.Errorf("%q 不是合法的 Go 标识符", newName)
+	}
+	pkg, obj, refs := t.pkg, t.obj, t.refs
+	if obj.Name() == newName {
+		return nil, errors.New("新名称与原名称相同")
+	}
+
+	plan := &Plan{OldName: obj.Name(), NewName: newName}
+	if len(pkg.errors) > 0 {
+		plan.Warnings = append(plan.Warnings, fmt.Sprintf("包存在 %d 个类型错误，重命名结果可能不完整：%v", len(pkg.errors), pkg.errors[0]))
+	}
+	if obj.Exported() && (obj.Parent() == pkg.types.Scope() || isMember(obj)) {
+		plan.Warnings = append(plan.Warnings, fmt.Sprintf("%s 为导出标识符，其它包中的引用不会被修改", obj.Name()))
+	}
+	if fn, ok := obj.(*types.Func); ok && isMember(fn) {
+		plan.Warnings = append(plan.Warnings, "重命名方法可能导致类型不再满足某些接口")
+	}
+	if obj.Exported() != token.IsExported(newName) {
+		plan.Warnings = append(plan.Warnings, "新名称改变了标识符的导出性")
+	}
+
+	if err := pkg.checkConflicts(obj, refs, newName); err != nil {
+		return nil, err
+	}
+
+	byFile := make(map[string][]*ast.Ident)
+	for _, id := range refs {
+		name := pkg.fset.Position(id.Pos()).Filename
+		byFile[name] = append(byFile[name], id)
+	}
+
+	names := make([]string, 0, len(byFile))
+	for name := range byFile {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		before := pkg.sources[name]
+		ids := append([]*ast.Ident(nil), byFile[name]...)
+		sort.Slice(ids, func(i, j int) bool { return ids[i].Pos() > ids[j].Pos() })
+
+		after := append([]byte(nil), before...)
+		for _, id := range ids {
+			offset := pkg.fset.Position(id.Pos()).Offset
+			after = append(after[:offset], append([]byte(newName), after[offset+len(id.Name):]...)...)
+			plan.Edits = append(plan.Edits, Edit{Path: name, Offset: offset, Length: len(id.Name)})
+		}
+		// 仅当原文件已符合 gofmt 时才重新格式化，避免引入无关改动。
+		if formatted, err := format.Source(before); err == nil && bytes.Equal(formatted, before) {
+			if formatted, err := format.Source(after); err == nil {
+				after = formatted
+			}
+		}
+		plan.Files = append(plan.Files, FileChange{Path: name, Before: before, After: after})
+		plan.References += len(ids)
+	}
+
+	return plan, nil
+}
+
+// Apply 将重命名写回磁盘，若文件在预览后被修改则拒绝写入。
+func (p *Plan) Apply() error {
+	for _, change := range p.Files {
+		current, err := os.ReadFile(change.Path)
+		if err != nil {
+			return fmt.Errorf("读取 %s 失败: %w", change.Path, err)
+		}
+		if !bytes.Equal(current, change.Before) {
+			return fmt.Errorf("%s 在预览后已被修改，请重新生成预览", change.Path)
+		}
+	}
+	for _, change := range p.Files {
+		info, err := os.Stat(change.Path)
+		if err != nil {
+			return fmt.Errorf("读取 %s 失败: %w", change.Path, err)
+		}
+		if err := os.WriteFile(change.Path, change.After, info.Mode().Perm()); err != nil {
+			return fmt.Errorf("写入 %s 失败: %w", change.Path, err)
+		}
+	}
+	return nil
+}
+
+// Diff 以统一 diff 格式返回全部改动。
+func (p *Plan) Diff() string {
+	var b strings.Builder
+	for _, change := range p.Files {
+		b.WriteString(unifiedDiff(change.Path, change.Before, change.After))
+	}
+	return b.String()
+}
+
+type loadedPackage struct {
+	fset    *token.FileSet
+	files   []*ast.File
+	sources map[string][]byte
+	types   *types.Package
+	info    *types.Info
+	errors  []error
+}
+
+func loadPackage(path string, overlay map[string][]byte) (*loadedPackage, error) {
+	readFile := func(name string) ([]byte, error) {
+		if src, ok := overlay[name]; ok {
+			return src, nil
+		}
+		return os.ReadFile(name)
+	}
+
+	fset := token.NewFileSet()
+	targetSrc, err := readFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("读取源文件失败: %w", err)
+	}
+	target, err := parser.ParseFile(fset, path, targetSrc, parser.ParseComments)
+	if err != nil {
+		return nil, fmt.Errorf("解析源文件失败: %w", err)
+	}
+
+	pkg := &loadedPackage{
+		fset:    fset,
+		files:   []*ast.File{target},
+		sources: map[string][]byte{path: targetSrc},
+	}
+
+	// 按构建约束筛选同目录的文件，避免不同平台的实现互相重复声明。
+	ctxt := buildContext(path, readFile)
+	dir := filepath.Dir(path)
+	matches, err := filepath.Glob(filepath.Join(dir, "*.go"))
+	if err != nil {
+		return nil, err
+	}
+	for _, name := range matches {
+		if name == path {
+			continue
+		}
+		if ok, err := ctxt.MatchFile(dir, filepath.Base(name)); err != nil || !ok {
+			continue
+		}
+		src, err := readFile(name)
+		if err != nil {
+			return nil, fmt.Errorf("读取 %s 失败: %w", name, err)
+		}
+		file, err := parser.ParseFile(fset, name, src, parser.ParseComments)
+		if err != nil || file.Name.Name != target.Name.Name {
+			// 跳过无法解析的文件，以及与目标文件不属于同一个包的文件（外部测试包 xxx_test 与主包互相排除）。
+			continue
+		}
+		pkg.files = append(pkg.files, file)
+		pkg.sources[name] = src
+	}
+
+	pkg.info = &types.Info{
+		Defs:       make(map[*ast.Ident]types.Object),
+		Uses:       make(map[*ast.Ident]types.Object),
+		Implicits:  make(map[ast.Node]types.Object),
+		Selections: make(map[*ast.SelectorExpr]*types.Selection),
+		Scopes:     make(map[ast.Node]*types.Scope),
+	}
+	conf := types.Config{
+		Importer: importer.ForCompiler(fset, "source", nil),
+		Sizes:    types.SizesFor("gc", ctxt.GOARCH),
+		Error:    func(err error) { pkg.errors = append(pkg.errors, err) },
+	}
+	// 类型错误通过 Error 回调收集，尽量在不完整的包上继续工作。
+	pkg.types, _ = conf.Check(target.Name.Name, fset, pkg.files, pkg.info)
+	return pkg, nil
+}
+
+func (p *loadedPackage) identAt(path string, line, column int) *ast.Ident {
+	var found *ast.Ident
+	for _, file := range p.files {
+		if p.fset.Position(file.Pos()).Filename != path {
+			continue
+		}
+		ast.Inspect(file, func(n ast.Node) bool {
+			id, ok := n.(*ast.Ident)
+			if !ok || found != nil {
+				return found == nil
+			}
+			pos := p.fset.Position(id.Pos())
+			if pos.Line == line && column >= pos.Column && column < pos.Column+len(id.Name) {
+				found = id
+			}
+			return true
+		})
+	}
+	return found
+}
+
+// references 返回指向 obj 的全部标识符，包括定义处。
+func (p *loadedPackage) references(obj types.Object) []*ast.Ident {
+	var refs []*ast.Ident
+	for id, o := range p.info.Defs {
+		if o == obj {
+			refs = append(refs, id)
+		}
+	}
+	for id, o := range p.info.Uses {
+		if o == obj {
+			refs = append(refs, id)
+		}
+	}
+	return refs
+}
+
+func (p *loadedPackage) checkConflicts(obj types.Object, refs []*ast.Ident, newName string) error {
+	if isMember(obj) {
+		return p.checkMemberConflict(obj, newName)
+	}
+
+	declScope := obj.Parent()
+	if declScope == nil {
+		return fmt.Errorf("无法确定 %s 的作用域", obj.Name())
+	}
+	if existing := declScope.Lookup(newName); existing != nil {
+		return fmt.Errorf("作用域内已存在同名标识符 %s（%s）", newName, p.fset.Position(existing.Pos()))
+	}
+	if declScope == p.types.Scope() {
+		for _, file := range p.files {
+			if scope := p.info.Scopes[file]; scope != nil && scope.Lookup(newName) != nil {
+				return fmt.Errorf("%s 与 %s 中导入的包名冲突", newName, p.fset.Position(file.Pos()).Filename)
+			}
+		}
+	}
+
+	// 新名称不能被引用处更内层的同名声明遮蔽。
+	for _, id := range refs {
+		scope := p.types.Scope().Innermost(id.Pos())
+		if scope == nil {
+			continue
+		}
+		if found, shadow := scope.LookupParent(newName, id.Pos()); shadow != nil && isInside(found, declScope) && found != declScope {
+			return fmt.Errorf("%s 处的引用会被 %s 的同名声明遮蔽", p.fset.Position(id.Pos()), p.fset.Position(shadow.Pos()))
+		}
+	}
+
+	// 已有的同名引用不能因重命名而改为指向 obj。
+	for id, used := range p.info.Uses {
+		if id.Name != newName || used == nil {
+			continue
+		}
+		scope := p.types.Scope().Innermost(id.Pos())
+		if scope == nil {
+			continue
+		}
+		if _, visible := scope.LookupParent(obj.Name(), id.Pos()); visible != obj {
+			continue
+		}
+		if usedScope := used.Parent(); usedScope != nil && usedScope != declScope && isInside(declScope, usedScope) {
+			return fmt.Errorf("重命名后 %s 处对 %s 的引用会被遮蔽", p.fset.Position(id.Pos()), newName)
+		}
+	}
+	return nil
+}
+
+func (p *loadedPackage) checkMemberConflict(obj types.Object, newName string) error {
+	var recv types.Type
+	switch o := obj.(type) {
+	case *types.Func:
+		sig, _ := o.Type().(*types.Signature)
+		if sig == nil || sig.Recv() == nil {
+			return nil
+		}
+		recv = sig.Recv().Type()
+	case *types.Var:
+		// 字段：在包内查找拥有该字段的结构体。
+		for _, def := range p.info.Defs {
+			tn, ok := def.(*types.TypeName)
+			if !ok {
+				continue
+			}
+			if st, ok := tn.Type().Underlying().(*types.Struct); ok {
+				for i := 0; i < st.NumFields(); i++ {
+					if st.Field(i) == o {
+						recv = tn.Type()
+					}
+				}
+			}
+		}
+	}
+	if recv == nil {
+		return nil
+	}
+	if existing, _, _ := types.LookupFieldOrMethod(recv, true, obj.Pkg(), newName); existing != nil {
+		return fmt.Errorf("%s 已存在名为 %s 的字段或方法", recv, newName)
+	}
+	return nil
+}
+
+func isMember(obj types.Object) bool {
+	switch o := obj.(type) {
+	case *types.Var:
+		return o.IsField()
+	case *types.Func:
+		sig, _ := o.Type().(*types.Signature)
+		return sig != nil && sig.Recv() != nil
+	}
+	return false
+}
+
+// isInside 判断 inner 是否位于 outer 之内（含相等）。
+func isInside(inner, outer *types.Scope) bool {
+	for s := inner; s != nil; s = s.Parent() {
+		if s == outer {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,255 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"mime"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/yanzzp/name-sprout/internal/app"
+	"github.com/yanzzp/name-sprout/internal/providers"
+)
+
+const (
+	defaultTimeout       = 60 * time.Second
+	defaultMaxConcurrent = 4
+	maxBodyBytes         = 1 << 20
+)
+
+// Options 控制 HTTP API 的超时与并发。
+type Options struct {
+	// Timeout 为单次生成的超时时间，默认 60 秒。
+	Timeout time.Duration
+	// MaxConcurrent 为同时进行的生成请求上限，超出时返回 429，默认 4。
+	MaxConcurrent int
+	// ErrorLog 记录提供方调用失败的详细原因，默认输出到标准错误。
+	ErrorLog *log.Logger
+}
+
+// Server 以 JSON API 的形式暴露命名能力。
+type Server struct {
+	svc     *app.Service
+	timeout time.Duration
+	slots   chan struct{}
+	mux     *http.ServeMux
+	log     *log.Logger
+}
+
+// New 构造 HTTP API 服务。
+func New(svc *app.Service, opts Options) *Server {
+	if opts.Timeout <= 0 {
+		opts.Timeout = defaultTimeout
+	}
+	if opts.MaxConcurrent <= 0 {
+		opts.MaxConcurrent = defaultMaxConcurrent
+	}
+	if opts.ErrorLog == nil {
+		opts.ErrorLog = log.New(os.Stderr, "namesprout serve: ", log.LstdFlags)
+	}
+
+	s := &Server{
+		svc:     svc,
+		timeout: opts.Timeout,
+		slots:   make(chan struct{}, opts.MaxConcurrent),
+		mux:     http.NewServeMux(),
+		log:     opts.ErrorLog,
+	}
+	s.mux.HandleFunc("POST /v1/names", s.handleNames)
+	s.mux.HandleFunc("GET /v1/providers", s.handleProviders)
+	s.mux.HandleFunc("GET /v1/styles", s.handleStyles)
+	s.mux.HandleFunc("GET /v1/kinds", s.handleKinds)
+	return s
+}
+
+// Timeout 返回单次生成的超时时间，便于调用方设置 http.Server 的写超时。
+func (s *Server) Timeout() time.Duration {
+	return s.timeout
+}
+
+// ServeHTTP 实现 http.Handler。
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mux.ServeHTTP(w, r)
+}
+
+type namesRequest struct {
+	Description string `json:"description"`
+	Kind        string `json:"kind"`
+	Style       string `json:"style"`
+	Count       int    `json:"count"`
+	Provider    string `json:"provider"`
+}
+
+type namesResponse struct {
+	Provider string                `json:"provider"`
+	Model    string                `json:"model,omitempty"`
+	Kind     providers.NameKind    `json:"kind"`
+	Style    providers.NamingStyle `json:"style"`
+	Names    []string              `json:"names"`
+	Dropped  []string              `json:"dropped,omitempty"`
+}
+
+func (s *Server) handleNames(w http.ResponseWriter, r *http.Request) {
+	// 只接受 application/json：浏览器跨站发送的 text/plain 等“简单请求”无需 CORS 预检，
+	// 不检查时任意网页都能借本机服务消耗用户的 API 配额。
+	if mediaType, _, err := mime.ParseMediaType(r.Header.Get("Content-Type")); err != nil || mediaType != "application/json" {
+		writeError(w, http.StatusUnsupportedMediaType, errors.New("Content-Type 必须为 application/json"))
+		return
+	}
+
+	var body namesRequest
+	dec := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxBodyBytes))
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(&body); err != nil {
+		writeError(w, http.StatusBadRequest, fmt.Errorf("无法解析请求体: %w", err))
+		return
+	}
+	if strings.TrimSpace(body.Description) == "" {
+		writeError(w, http.StatusBadRequest, errors.New("description 不能为空"))
+		return
+	}
+	kind, err := providers.ParseNameKind(body.Kind)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+	if body.Count < 0 {
+		writeError(w, http.StatusBadRequest, errors.New("count 不能为负数"))
+		return
+	}
+	if body.Style != "" {
+		if _, _, ok := s.svc.Prompts().Lookup(body.Style); !ok {
+			writeError(w, http.StatusBadRequest, fmt.Errorf("不支持的命名格式: %s", body.Style))
+			return
+		}
+	}
+	if body.Provider != "" {
+		if _, ok := s.svc.App().Config().Provider(body.Provider); !ok {
+			writeError(w, http.StatusBadRequest, fmt.Errorf("未知的 Provider: %s", body.Provider))
+			return
+		}
+	}
+
+	select {
+	case s.slots <- struct{}{}:
+		defer func() { <-s.slots }()
+	default:
+		writeError(w, http.StatusTooManyRequests, errors.New("并发请求过多，请稍后重试"))
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), s.timeout)
+	defer cancel()
+
+	result, err := s.svc.Suggest(ctx, app.SuggestOptions{
+		RequestOptions: app.RequestOptions{
+			Description: body.Description,
+			Kind:        kind,
+			Style:       body.Style,
+			Count:       body.Count,
+		},
+		Provider: body.Provider,
+		Source:   "serve",
+	})
+	switch {
+	case errors.Is(err, context.DeadlineExceeded):
+		writeError(w, http.StatusGatewayTimeout, errors.New("生成超时"))
+		return
+	case err != nil:
+		// 原始错误可能包含密钥命令及其输出，只写入服务端日志。
+		s.log.Printf("生成失败（provider=%q）：%v", body.Provider, err)
+		writeError(w, http.StatusBadGateway, errors.New("调用模型提供方失败，详情见服务端日志"))
+		return
+	}
+
+	writeJSON(w, http.StatusOK, namesResponse{
+		Provider: result.Provider,
+		Model:    result.Model,
+		Kind:     result.Request.Kind,
+		Style:    result.Request.NamingStyle,
+		Names:    nonNil(result.Names),
+		Dropped:  result.Dropped,
+	})
+}
+
+type providerInfo struct {
+	Name        string `json:"name"`
+	Type        string `json:"type"`
+	DisplayName string `json:"display_name"`
+	Model       string `json:"model,omitempty"`
+	Default     bool   `json:"default"`
+}
+
+func (s *Server) handleProviders(w http.ResponseWriter, r *http.Request) {
+	a := s.svc.App()
+	list := make([]providerInfo, 0)
+	for _, name := range a.ProviderNames() {
+		settings, _ := a.Config().Provider(name)
+		list = append(list, providerInfo{
+			Name:        name,
+			Type:        settings.Type,
+			DisplayName: providers.DisplayName(settings.Type),
+			Model:       settings.Model,
+			Default:     name == a.DefaultProviderName(),
+		})
+	}
+	writeJSON(w, http.StatusOK, list)
+}
+
+type styleInfo struct {
+	ID      providers.NamingStyle `json:"id"`
+	Label   string                `json:"label"`
+	Aliases []string              `json:"aliases"`
+}
+
+func (s *Server) handleStyles(w http.ResponseWriter, r *http.Request) {
+	lib := s.svc.Prompts()
+	list := make([]styleInfo, 0)
+	for _, style := range lib.Styles() {
+		def, _ := lib.Definition(style)
+		list = append(list, styleInfo{ID: style, Label: def.Label, Aliases: nonNil(def.Aliases)})
+	}
+	writeJSON(w, http.StatusOK, list)
+}
+
+type kindInfo struct {
+	ID           providers.NameKind    `json:"id"`
+	Label        string                `json:"label"`
+	DefaultStyle providers.NamingStyle `json:"default_style,omitempty"`
+}
+
+func (s *Server) handleKinds(w http.ResponseWriter, r *http.Request) {
+	lib := s.svc.Prompts()
+	list := make([]kindInfo, 0)
+	for _, kind := range lib.Kinds() {
+		def, _ := lib.KindDefinition(kind)
+		label := def.Label
+		if label == "" {
+			label = string(kind)
+		}
+		list = append(list, kindInfo{ID: kind, Label: label, DefaultStyle: def.DefaultStyle})
+	}
+	writeJSON(w, http.StatusOK, list)
+}
+
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(v)
+}
+
+func writeError(w http.ResponseWriter, status int, err error) {
+	writeJSON(w, status, map[string]string{"error": err.Error()})
+}
+
+func nonNil(values []string) []string {
+	if values == nil {
+		return []string{}
+	}
+	return values
+}
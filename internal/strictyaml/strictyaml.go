@@ -0,0 +1,183 @@
+// Package strictyaml 以严格模式解析 YAML：拒绝目标结构中不存在的字段，并给出行号与拼写建议。
+package strictyaml
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"reflect"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// UnknownFieldError 描述 YAML 中出现的未知字段。
+type UnknownFieldError struct {
+	Line int
+	// Path 为字段所在位置的点分路径，顶层字段为空。
+	Path  string
+	Field string
+	// Suggestion 为拼写最接近的合法字段，找不到时为空。
+	Suggestion string
+}
+
+func (e *UnknownFieldError) Error() string {
+	field := e.Field
+	if e.Path != "" {
+		field = e.Path + "." + e.Field
+	}
+	msg := fmt.Sprintf("第 %d 行：未知字段 %s", e.Line, field)
+	if e.Suggestion != "" {
+		msg += fmt.Sprintf("，是否想写 %s？", e.Suggestion)
+	}
+	return msg
+}
+
+// Unmarshal 将 raw 解析到 out。存在未知字段时返回由 *UnknownFieldError 组成的 errors.Join 结果，
+// 一次列出全部问题而不是只报告第一个。
+func Unmarshal(raw []byte, out any) error {
+	var root yaml.Node
+	if err := yaml.Unmarshal(raw, &root); err != nil {
+		return err
+	}
+
+	var problems []error
+	check(&root, reflect.TypeOf(out), "", &problems)
+	if len(problems) > 0 {
+		return errors.Join(problems...)
+	}
+
+	dec := yaml.NewDecoder(bytes.NewReader(raw))
+	dec.KnownFields(true)
+	if err := dec.Decode(out); err != nil && !errors.Is(err, io.EOF) {
+		return err
+	}
+	return nil
+}
+
+// check 按目标类型遍历节点树，记录结构体映射中无法对应到字段的 key。
+func check(node *yaml.Node, t reflect.Type, path string, problems *[]error) {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	switch node.Kind {
+	case yaml.DocumentNode:
+		for _, child := range node.Content {
+			check(child, t, path, problems)
+		}
+		return
+	case yaml.AliasNode:
+		if node.Alias != nil {
+			check(node.Alias, t, path, problems)
+		}
+		return
+	}
+
+	switch t.Kind() {
+	case reflect.Struct:
+		if node.Kind != yaml.MappingNode {
+			return
+		}
+		fields := structFields(t)
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			key, value := node.Content[i], node.Content[i+1]
+			field, ok := fields[key.Value]
+			if !ok {
+				*problems = append(*problems, &UnknownFieldError{
+					Line:       key.Line,
+					Path:       path,
+					Field:      key.Value,
+					Suggestion: suggest(key.Value, fields),
+				})
+				continue
+			}
+			check(value, field, join(path, key.Value), problems)
+		}
+	case reflect.Map:
+		if node.Kind != yaml.MappingNode {
+			return
+		}
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			check(node.Content[i+1], t.Elem(), join(path, node.Content[i].Value), problems)
+		}
+	case reflect.Slice, reflect.Array:
+		if node.Kind != yaml.SequenceNode {
+			return
+		}
+		for i, child := range node.Content {
+			check(child, t.Elem(), fmt.Sprintf("%s[%d]", path, i), problems)
+		}
+	}
+}
+
+// structFields 返回结构体中可由 YAML 填充的字段名及其类型，规则与 yaml.v3 一致：优先取 yaml 标签，否则为小写字段名。
+func structFields(t reflect.Type) map[string]reflect.Type {
+	fields := make(map[string]reflect.Type, t.NumField())
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+		tag := field.Tag.Get("yaml")
+		if tag == "-" {
+			continue
+		}
+		name, opts, _ := strings.Cut(tag, ",")
+		if strings.Contains(opts, "inline") {
+			for inner, innerType := range structFields(field.Type) {
+				fields[inner] = innerType
+			}
+			continue
+		}
+		if name == "" {
+			name = strings.ToLower(field.Name)
+		}
+		fields[name] = field.Type
+	}
+	return fields
+}
+
+func join(path, key string) string {
+	if path == "" {
+		return key
+	}
+	return path + "." + key
+}
+
+// suggest 返回编辑距离最小且足够接近的字段名。
+func suggest(key string, fields map[string]reflect.Type) string {
+	best, bestDistance := "", 0
+	for name := range fields {
+		d := distance(strings.ToLower(key), name)
+		if best == "" || d < bestDistance || (d == bestDistance && name < best) {
+			best, bestDistance = name, d
+		}
+	}
+	if best == "" || bestDistance > max(2, len([]rune(best))/3) {
+		return ""
+	}
+	return best
+}
+
+// distance 计算两个字符串的 Levenshtein 编辑距离。
+func distance(a, b string) int {
+	ra, rb := []rune(a), []rune(b)
+	prev := make([]int, len(rb)+1)
+	curr := make([]int, len(rb)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(ra); i++ {
+		curr[0] = i
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			curr[j] = min(prev[j]+1, curr[j-1]+1, prev[j-1]+cost)
+		}
+		prev, curr = curr, prev
+	}
+	return prev[len(rb)]
+}
@@ -0,0 +1,117 @@
+package ui
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/yanzzp/name-sprout/internal/casing"
+	"github.com/yanzzp/name-sprout/internal/providers"
+)
+
+// copyFormat 描述复制面板中多个名称的拼接方式。
+type copyFormat struct {
+	label string
+	join  func(names []string) string
+}
+
+// copyFormats 将复制面板中的按键映射到拼接方式。
+var copyFormats = map[string]copyFormat{
+	"n": {label: "换行", join: func(names []string) string { return strings.Join(names, "\n") }},
+	",": {label: "逗号", join: func(names []string) string { return strings.Join(names, ", ") }},
+	"l": {label: "Markdown 列表", join: func(names []string) string {
+		lines := make([]string, len(names))
+		for i, name := range names {
+			lines[i] = "- `" + name + "`"
+		}
+		return strings.Join(lines, "\n")
+	}},
+}
+
+// toggleMark 切换光标所在名称的多选标记。
+func (m *Model) toggleMark() {
+	item, ok := m.selected()
+	if !ok {
+		return
+	}
+	if m.marked == nil {
+		m.marked = make(map[string]bool)
+	}
+	if m.marked[item.name] {
+		delete(m.marked, item.name)
+	} else {
+		m.marked[item.name] = true
+	}
+	m.moveCursor(1)
+}
+
+// copyTargets 返回复制面板要处理的名称：有多选标记时按列表顺序取全部标记项，否则取光标所在项。
+func (m *Model) copyTargets() []candidate {
+	var targets []candidate
+	for _, item := range m.candidates() {
+		if m.marked[item.name] {
+			targets = append(targets, item)
+		}
+	}
+	if len(targets) == 0 {
+		if item, ok := m.selected(); ok {
+			targets = append(targets, item)
+		}
+	}
+	return targets
+}
+
+func (m *Model) openCopyPanel() {
+	m.copying = true
+	keys := []string{"N 换行", ", 逗号", "L Markdown 列表"}
+	for i, style := range providers.AllNamingStyles {
+		keys = append(keys, fmt.Sprintf("%d %s", i+1, style))
+	}
+	m.status = fmt.Sprintf("复制 %d 个名称：%s  其它键取消", len(m.copyTargets()), strings.Join(keys, "  "))
+}
+
+func (m *Model) handleCopyKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	key := strings.ToLower(msg.String())
+	if key == "ctrl+c" {
+		return m, tea.Quit
+	}
+	m.copying = false
+
+	targets := m.copyTargets()
+	names := make([]string, len(targets))
+	for i, item := range targets {
+		names[i] = item.name
+	}
+
+	var text, label string
+	if format, ok := copyFormats[key]; ok {
+		text, label = format.join(names), format.label
+	} else if index, err := strconv.Atoi(key); err == nil && index >= 1 && index <= len(providers.AllNamingStyles) {
+		// 按其它命名格式复制时逐个转换，多个名称以换行分隔。
+		style := providers.AllNamingStyles[index-1]
+		converted := make([]string, len(names))
+		for i, name := range names {
+			converted[i] = casing.Convert(name, style)
+		}
+		text, label = strings.Join(converted, "\n"), string(style)
+	} else {
+		m.status = "已取消复制。"
+		return m, nil
+	}
+
+	hint, err := m.writeClipboard(text, targets...)
+	if err != nil {
+		m.err = err
+		return m, nil
+	}
+	m.err = nil
+	m.marked = nil
+	if len(targets) == 1 {
+		m.status = fmt.Sprintf("已复制（%s）：%s", label, text) + hint
+	} else {
+		m.status = fmt.Sprintf("已复制 %d 个名称（%s）。", len(targets), label) + hint
+	}
+	return m, nil
+}
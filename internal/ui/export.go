@@ -0,0 +1,82 @@
+package ui
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/yanzzp/name-sprout/internal/export"
+)
+
+// exportKeys 将导出面板中的按键映射到导出格式，大小写均可。
+var exportKeys = map[string]string{
+	"j": export.FormatJSON,
+	"J": export.FormatJSON,
+	"m": export.FormatMarkdown,
+	"M": export.FormatMarkdown,
+	"c": export.FormatCSV,
+	"C": export.FormatCSV,
+}
+
+func (m *Model) handleExportKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	key := msg.String()
+	if key == "ctrl+c" {
+		return m, tea.Quit
+	}
+	m.exporting = false
+	format, ok := exportKeys[key]
+	if !ok {
+		m.status = "已取消导出。"
+		return m, nil
+	}
+
+	path, err := m.exportTo(format)
+	if err != nil {
+		m.err = fmt.Errorf("导出失败: %w", err)
+		return m, nil
+	}
+	m.err = nil
+	m.status = fmt.Sprintf("已导出到 %s", path)
+	return m, nil
+}
+
+// exportTo 将当前候选写入导出目录下带时间戳的文件。
+// 先写入同目录下的临时文件再重命名，编码或写入失败时不会留下不完整的导出文件。
+func (m *Model) exportTo(format string) (string, error) {
+	dir := m.exportDir
+	if dir == "" {
+		dir = "."
+	}
+	name := fmt.Sprintf("namesprout-%s%s", time.Now().Format("20060102-150405"), export.Extension(format))
+	path := filepath.Join(dir, name)
+
+	var names []string
+	for _, item := range m.candidates() {
+		names = append(names, item.name)
+	}
+	doc := export.NewDocument(m.request, m.providerName, m.modelName, names)
+
+	f, err := os.CreateTemp(dir, "."+name+".*")
+	if err != nil {
+		return "", err
+	}
+	tmp := f.Name()
+	err = export.Write(f, format, doc)
+	if cerr := f.Close(); err == nil {
+		err = cerr
+	}
+	if err == nil {
+		err = os.Chmod(tmp, 0o644)
+	}
+	if err == nil {
+		err = os.Rename(tmp, path)
+	}
+	if err != nil {
+		os.Remove(tmp)
+		return "", err
+	}
+	return path, nil
+}
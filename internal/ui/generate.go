@@ -0,0 +1,156 @@
+package ui
+
+import (
+	"context"
+	"errors"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/yanzzp/name-sprout/internal/collision"
+	"github.com/yanzzp/name-sprout/internal/glossary"
+	"github.com/yanzzp/name-sprout/internal/history"
+	"github.com/yanzzp/name-sprout/internal/providers"
+)
+
+// candidateMsg 携带流式生成中新到达的一个名称，next 用于继续等待后续消息。
+type candidateMsg struct {
+	id   int
+	name string
+	next tea.Cmd
+}
+
+// generation 是一次生成请求的快照，在命令的 goroutine 中使用，避免并发访问 Model。
+type generation struct {
+	id           int
+	provider     providers.Provider
+	request      providers.Request
+	glossary     *glossary.Glossary
+	collisions   *collision.Index
+	dropExisting bool
+	store        *history.Store
+	providerName string
+	modelName    string
+}
+
+// generateCmd 发起一次新的生成请求，上下文由 Model 持有以便随时取消。
+// Provider 支持流式生成时逐个推送名称，否则等待完整结果。
+func (m *Model) generateCmd() tea.Cmd {
+	m.requestID++
+	gen := generation{
+		id:           m.requestID,
+		provider:     m.provider,
+		request:      m.request,
+		glossary:     m.glossary,
+		collisions:   m.collisions,
+		dropExisting: m.dropExisting,
+		store:        m.store,
+		providerName: m.providerName,
+		modelName:    m.modelName,
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), defaultTimeout)
+	m.cancel = cancel
+
+	if streamer, ok := m.provider.(providers.StreamingProvider); ok {
+		return gen.stream(ctx, cancel, streamer)
+	}
+	return func() tea.Msg {
+		defer cancel()
+		names, err := gen.provider.GenerateNames(ctx, gen.request)
+		if err != nil {
+			return suggestionsMsg{id: gen.id, err: err}
+		}
+		msg := suggestionsMsg{id: gen.id}
+		gen.filter(&msg, names)
+		gen.record(&msg)
+		return msg
+	}
+}
+
+// stream 在后台读取流式结果，每个通过过滤的名称都会作为 candidateMsg 推送，最后推送完整的 suggestionsMsg。
+func (g generation) stream(ctx context.Context, cancel context.CancelFunc, streamer providers.StreamingProvider) tea.Cmd {
+	updates := make(chan tea.Msg)
+	next := waitForUpdate(ctx, g.id, updates)
+	go func() {
+		defer cancel()
+		defer close(updates)
+
+		send := func(msg tea.Msg) bool {
+			select {
+			case updates <- msg:
+				return true
+			case <-ctx.Done():
+				return false
+			}
+		}
+
+		candidates, err := streamer.StreamNames(ctx, g.request)
+		if err != nil {
+			send(suggestionsMsg{id: g.id, err: err})
+			return
+		}
+		msg := suggestionsMsg{id: g.id}
+		for candidate := range candidates {
+			if candidate.Err != nil {
+				send(suggestionsMsg{id: g.id, err: candidate.Err})
+				return
+			}
+			before := len(msg.names)
+			g.filter(&msg, []string{candidate.Name})
+			if len(msg.names) > before && !send(candidateMsg{id: g.id, name: candidate.Name, next: next}) {
+				return
+			}
+		}
+		if ctx.Err() != nil {
+			return
+		}
+		g.record(&msg)
+		send(msg)
+	}()
+	return next
+}
+
+// waitForUpdate 等待流式生成的下一条消息。通道提前关闭（超时或取消）时以错误结束本次生成。
+func waitForUpdate(ctx context.Context, id int, updates <-chan tea.Msg) tea.Cmd {
+	return func() tea.Msg {
+		msg, ok := <-updates
+		if !ok {
+			err := ctx.Err()
+			if err == nil {
+				err = errors.New("生成意外中断")
+			}
+			return suggestionsMsg{id: id, err: err}
+		}
+		return msg
+	}
+}
+
+// filter 剔除违反术语表的名称，并在配置时剔除已存在的名称，结果追加到 msg。
+func (g generation) filter(msg *suggestionsMsg, names []string) {
+	kept, dropped := g.glossary.Filter(names)
+	msg.dropped = append(msg.dropped, dropped...)
+	if g.dropExisting {
+		var duplicates []string
+		kept, duplicates = g.collisions.Partition(kept)
+		msg.duplicates = append(msg.duplicates, duplicates...)
+	}
+	msg.names = append(msg.names, kept...)
+}
+
+// record 将生成结果写入历史，并把记录 ID 回填到 msg。
+func (g generation) record(msg *suggestionsMsg) {
+	if g.store == nil {
+		return
+	}
+	entry, err := g.store.Record(history.Entry{
+		Source:      "tui",
+		Description: g.request.Description,
+		Kind:        g.request.Kind,
+		Style:       g.request.NamingStyle,
+		Provider:    g.providerName,
+		Model:       g.modelName,
+		Candidates:  msg.names,
+	})
+	if err == nil {
+		msg.entryID = entry.ID
+	}
+}
@@ -0,0 +1,154 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/yanzzp/name-sprout/internal/history"
+)
+
+const historyPageSize = 10
+
+type historyLoadedMsg struct {
+	entries []history.Entry
+	err     error
+}
+
+// historyPanel 展示历史记录，支持按关键字搜索并重新复制名称。
+type historyPanel struct {
+	open    bool
+	input   textinput.Model
+	entries []history.Entry
+	items   []history.Item
+	cursor  int
+}
+
+func newHistoryPanel() historyPanel {
+	input := textinput.New()
+	input.Placeholder = "输入关键字搜索名称或描述"
+	input.Prompt = "🔍 "
+	return historyPanel{input: input}
+}
+
+func (p *historyPanel) refresh() {
+	p.items = history.Search(p.entries, p.input.Value())
+	if p.cursor >= len(p.items) {
+		p.cursor = 0
+	}
+}
+
+func (p *historyPanel) selected() (history.Item, bool) {
+	if len(p.items) == 0 {
+		return history.Item{}, false
+	}
+	return p.items[p.cursor], true
+}
+
+func loadHistoryCmd(store *history.Store) tea.Cmd {
+	return func() tea.Msg {
+		entries, err := store.List()
+		return historyLoadedMsg{entries: entries, err: err}
+	}
+}
+
+func (m *Model) openHistory() tea.Cmd {
+	m.history.open = true
+	m.history.cursor = 0
+	m.history.input.SetValue("")
+	m.history.input.Focus()
+	return tea.Batch(textinput.Blink, loadHistoryCmd(m.store))
+}
+
+func (m *Model) handleHistoryKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	panel := &m.history
+	switch msg.String() {
+	case "ctrl+c":
+		return m, tea.Quit
+	case "esc":
+		panel.open = false
+		panel.input.Blur()
+		return m, nil
+	case "up":
+		if len(panel.items) > 0 {
+			panel.cursor = (panel.cursor - 1 + len(panel.items)) % len(panel.items)
+		}
+		return m, nil
+	case "down":
+		if len(panel.items) > 0 {
+			panel.cursor = (panel.cursor + 1) % len(panel.items)
+		}
+		return m, nil
+	case "enter":
+		if item, ok := panel.selected(); ok {
+			if hint, err := m.writeClipboard(item.Name, candidate{name: item.Name, entryID: item.Entry.ID}); err != nil {
+				m.err = err
+			} else {
+				m.err = nil
+				m.status = fmt.Sprintf("已从历史复制：%s", item.Name) + hint
+			}
+		}
+		return m, nil
+	}
+
+	var cmd tea.Cmd
+	panel.input, cmd = panel.input.Update(msg)
+	panel.refresh()
+	return m, cmd
+}
+
+func (m *Model) renderHistory(sections []string) string {
+	panel := &m.history
+	sections = append(sections, infoStyle.Render("历史记录"), panel.input.View())
+
+	if len(panel.items) == 0 {
+		sections = append(sections, faintStyle.Render("没有匹配的历史记录。"))
+	} else {
+		start := 0
+		if panel.cursor >= historyPageSize {
+			start = panel.cursor - historyPageSize + 1
+		}
+		end := min(start+historyPageSize, len(panel.items))
+
+		var rows []string
+		for i := start; i < end; i++ {
+			item := panel.items[i]
+			prefix, style := "  ", listItemStyle
+			if i == panel.cursor {
+				prefix, style = "▶ ", selectedItemStyle
+			}
+			mark := ""
+			if item.Copied {
+				mark = " ✓"
+			}
+			if item.Pinned {
+				mark += " 📌"
+			}
+			meta := fmt.Sprintf("%s · %s · %s", item.Entry.Time.Format("2006-01-02 15:04"), item.Entry.Kind, truncate(item.Entry.Description, 40))
+			rows = append(rows, prefix+style.Render(item.Name)+mark+"  "+faintStyle.Render(meta))
+		}
+		sections = append(sections, strings.Join(rows, "\n"))
+		sections = append(sections, faintStyle.Render(fmt.Sprintf("共 %d 条", len(panel.items))))
+	}
+
+	if m.status != "" {
+		sections = append(sections, faintStyle.Render(m.status))
+	}
+	if m.err != nil {
+		sections = append(sections, errStyle.Render(m.err.Error()))
+	}
+	sections = append(sections, faintStyle.Render("操作：输入关键字搜索  ↑↓ 选择  Enter 复制  Esc 返回"))
+	return containerStyle.Render(strings.Join(sections, "\n\n"))
+}
+
+// truncate 按字符截断文本并折叠换行，用于单行展示。
+func truncate(text string, limit int) string {
+	text = strings.Join(strings.Fields(text), " ")
+	runes := []rune(text)
+	if len(runes) <= limit {
+		return text
+	}
+	return string(runes[:limit]) + "…"
+}
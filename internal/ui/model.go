@@ -7,13 +7,19 @@ import (
 	"strings"
 	"time"
 
-	"github.com/atotto/clipboard"
 	"github.com/charmbracelet/bubbles/spinner"
+	"github.com/charmbracelet/bubbles/viewport"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
 
+	"github.com/yanzzp/name-sprout/internal/clipboard"
+	"github.com/yanzzp/name-sprout/internal/codectx"
+	"github.com/yanzzp/name-sprout/internal/collision"
 	"github.com/yanzzp/name-sprout/internal/config"
+	"github.com/yanzzp/name-sprout/internal/glossary"
+	"github.com/yanzzp/name-sprout/internal/history"
 	"github.com/yanzzp/name-sprout/internal/providers"
+	"github.com/yanzzp/name-sprout/internal/rename"
 )
 
 const (
@@ -22,8 +28,42 @@ const (
 )
 
 type suggestionsMsg struct {
-	names []string
-	err   error
+	id         int
+	names      []string
+	dropped    []string
+	duplicates []string
+	entryID    string
+	err        error
+}
+
+type renamePlanMsg struct {
+	plan *rename.Plan
+	err  error
+}
+
+type renameAppliedMsg struct {
+	plan *rename.Plan
+	err  error
+}
+
+// Options 汇总 TUI 的可选能力，零值即为默认行为。
+type Options struct {
+	// Glossary 非空时用于剔除违反项目术语表的候选。
+	Glossary *glossary.Glossary
+	// RenameTarget 为代码上下文模式提取的 Go 符号，非空时允许将候选重命名回源码。
+	RenameTarget *codectx.Snippet
+	// Collisions 为已存在标识符的索引，命中的候选会被标记。
+	Collisions *collision.Index
+	// DropCollisions 为 true 时直接剔除已存在的候选而非标记。
+	DropCollisions bool
+	// History 非空时记录每次生成与复制，并提供历史记录面板。
+	History *history.Store
+	// ExportDir 为 X 导出文件的目录，默认当前目录。
+	ExportDir string
+	// Clipboard 为复制时使用的剪贴板，默认优先系统剪贴板并回退到 OSC 52。
+	Clipboard *clipboard.Clipboard
+	// PrintOnSelect 为 true 时按 Enter 选中名称后直接退出，由调用方通过 Chosen 输出。
+	PrintOnSelect bool
 }
 
 // Model 展示命名候选并允许复制。
@@ -35,10 +75,35 @@ type Model struct {
 	showDetails  bool
 	temperature  *float32
 	topK         *float32
+	glossary     *glossary.Glossary
+	collisions   *collision.Index
+	dropExisting bool
+	store        *history.Store
+	entryID      string
+	history      historyPanel
+	exportDir    string
+	clipboard    *clipboard.Clipboard
+	printMode    bool
+	chosen       string
+	exporting    bool
+	copying      bool
 
 	spinner spinner.Model
+	width   int
+	height  int
+
+	renameTarget *codectx.Snippet
+	renamePlan   *rename.Plan
+	renaming     bool
+	diffView     viewport.Model
+
+	// requestID 标识最近一次生成请求并显示在界面上，旧请求迟到的结果会被忽略。
+	requestID int
+	cancel    context.CancelFunc
 
 	suggestions []string
+	pinned      []candidate
+	marked      map[string]bool
 	cursor      int
 	loading     bool
 	err         error
@@ -46,7 +111,7 @@ type Model struct {
 }
 
 // NewModel 创建用于展示命名结果的 TUI 模型。
-func NewModel(providerName string, provider providers.Provider, settings config.ProviderSettings, req providers.Request) (*Model, error) {
+func NewModel(providerName string, provider providers.Provider, settings config.ProviderSettings, req providers.Request, opts Options) (*Model, error) {
 	if err := warmupProvider(provider); err != nil {
 		return nil, fmt.Errorf("提供方初始化失败：%w", err)
 	}
@@ -79,26 +144,99 @@ func NewModel(providerName string, provider providers.Provider, settings config.
 		modelName:    modelName,
 		temperature:  temperature,
 		topK:         topK,
+		glossary:     opts.Glossary,
+		collisions:   opts.Collisions,
+		dropExisting: opts.DropCollisions,
+		store:        opts.History,
+		history:      newHistoryPanel(),
+		exportDir:    opts.ExportDir,
+		clipboard:    opts.Clipboard,
+		printMode:    opts.PrintOnSelect,
 		spinner:      sp,
+		diffView:     viewport.New(0, 0),
 		loading:      true,
 		status:       "正在等待模型响应...",
 	}
+	if model.clipboard == nil {
+		model.clipboard = clipboard.New(clipboard.ModeAuto, nil)
+	}
+	if target := opts.RenameTarget; target != nil && target.Parsed && target.Symbol != "" {
+		model.renameTarget = target
+	}
 
 	return model, nil
 }
 
 // Init 启动时立刻触发一次名称生成。
 func (m *Model) Init() tea.Cmd {
-	return tea.Batch(m.spinner.Tick, generateCmd(m.provider, m.request))
+	return tea.Batch(m.spinner.Tick, m.generateCmd())
 }
 
 // Update 处理 Bubble Tea 消息。
 func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	switch msg := msg.(type) {
 	case tea.KeyMsg:
+		if m.renamePlan != nil {
+			return m.handleRenameKey(msg)
+		}
+		if m.history.open {
+			return m.handleHistoryKey(msg)
+		}
+		if m.exporting {
+			return m.handleExportKey(msg)
+		}
+		if m.copying {
+			return m.handleCopyKey(msg)
+		}
 		return m.handleKey(msg)
+	case historyLoadedMsg:
+		if msg.err != nil {
+			m.err = fmt.Errorf("读取历史记录失败: %w", msg.err)
+		}
+		m.history.entries = msg.entries
+		m.history.refresh()
+		return m, nil
+	case tea.WindowSizeMsg:
+		m.width, m.height = msg.Width, msg.Height
+		m.resizeDiffView()
+		return m, nil
+	case renamePlanMsg:
+		m.renaming = false
+		if msg.err != nil {
+			m.err = fmt.Errorf("重命名预检失败: %w", msg.err)
+			m.status = "可选择其它候选后再次按 N 重试。"
+			return m, nil
+		}
+		m.err = nil
+		m.renamePlan = msg.plan
+		m.diffView.SetContent(renderDiff(msg.plan.Diff()))
+		m.diffView.GotoTop()
+		m.resizeDiffView()
+		return m, nil
+	case renameAppliedMsg:
+		m.renaming = false
+		if msg.err != nil {
+			m.err = fmt.Errorf("重命名失败: %w", msg.err)
+			m.status = "源码未被修改。"
+			return m, nil
+		}
+		m.err = nil
+		m.renameTarget.Symbol = msg.plan.NewName
+		m.status = fmt.Sprintf("已将 %s 重命名为 %s，共修改 %d 处引用、%d 个文件。", msg.plan.OldName, msg.plan.NewName, msg.plan.References, len(msg.plan.Files))
+		return m, nil
+	case candidateMsg:
+		if msg.id != m.requestID || !m.loading {
+			return m, nil
+		}
+		m.suggestions = append(m.suggestions, msg.name)
+		m.status = fmt.Sprintf("正在接收候选，已收到 %d 个...", len(m.suggestions))
+		return m, msg.next
 	case suggestionsMsg:
+		if msg.id != m.requestID || !m.loading {
+			return m, nil
+		}
 		m.loading = false
+		m.cancel = nil
 		if msg.err != nil {
 			m.err = msg.err
 			m.status = "生成失败，请检查配置或稍后重试。"
@@ -106,7 +244,14 @@ func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		}
 		m.err = nil
 		m.status = fmt.Sprintf("生成完成，共 %d 个候选。使用 ↑↓ 选择，Enter/C 复制。", len(msg.names))
+		if len(msg.dropped) > 0 {
+			m.status += fmt.Sprintf(" 已过滤 %d 个违反术语表的名称：%s", len(msg.dropped), strings.Join(msg.dropped, "、"))
+		}
+		if len(msg.duplicates) > 0 {
+			m.status += fmt.Sprintf(" 已剔除 %d 个已存在的名称：%s", len(msg.duplicates), strings.Join(msg.duplicates, "、"))
+		}
 		m.suggestions = msg.names
+		m.entryID = msg.entryID
 		m.cursor = 0
 		return m, nil
 	}
@@ -122,18 +267,33 @@ func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 func (m *Model) handleKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	switch msg.String() {
-	case "ctrl+c", "q", "esc":
+	case "ctrl+c", "q":
+		m.cancelGeneration()
 		return m, tea.Quit
-	case "r", "R":
-		if !m.loading {
-			m.loading = true
+	case "esc":
+		if m.loading {
+			m.cancelGeneration()
+			m.loading = false
 			m.err = nil
-			m.status = "正在等待模型响应..."
-			m.suggestions = nil
-			m.cursor = 0
-			return m, tea.Batch(m.spinner.Tick, generateCmd(m.provider, m.request))
+			m.status = "已取消本次生成，按 R 重新生成。"
+			return m, nil
 		}
+		return m, tea.Quit
+	case "r", "R":
+		// 生成中再次按 R 会取消当前请求并重新发起。
+		m.cancelGeneration()
+		m.loading = true
+		m.err = nil
+		m.status = "正在等待模型响应..."
+		// 固定的名称保留下来，只清空本轮生成结果。
+		m.suggestions = nil
+		m.marked = nil
+		m.cursor = 0
+		return m, tea.Batch(m.spinner.Tick, m.generateCmd())
 	case "enter":
+		if m.focusOnResults() && m.printMode {
+			return m, m.choose()
+		}
 		if m.focusOnResults() {
 			return m, m.copySelected()
 		}
@@ -141,19 +301,83 @@ func (m *Model) handleKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		if m.focusOnResults() {
 			return m, m.copySelected()
 		}
+	case " ":
+		if m.focusOnResults() {
+			m.togglePin()
+		}
+	case "tab":
+		if m.focusOnResults() {
+			m.toggleMark()
+		}
+	case "y", "Y":
+		if m.focusOnResults() {
+			m.openCopyPanel()
+		}
 	case "up":
 		m.moveCursor(-1)
 	case "down":
 		m.moveCursor(1)
 	case "i", "I":
 		m.showDetails = !m.showDetails
+	case "x", "X":
+		if m.focusOnResults() {
+			m.exporting = true
+			m.status = "选择导出格式：J JSON  M Markdown  C CSV  其它键取消"
+		}
+	case "h", "H":
+		if m.store != nil {
+			return m, m.openHistory()
+		}
+	case "n", "N":
+		if m.focusOnResults() && m.renameTarget != nil && !m.renaming {
+			m.renaming = true
+			m.status = "正在进行类型检查并生成重命名预览..."
+			item, _ := m.selected()
+			return m, prepareRenameCmd(m.renameTarget, item.name)
+		}
 	}
 
 	return m, nil
 }
 
+func (m *Model) handleRenameKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "ctrl+c":
+		return m, tea.Quit
+	case "y", "Y":
+		if m.renaming {
+			return m, nil
+		}
+		plan := m.renamePlan
+		m.renamePlan = nil
+		m.renaming = true
+		return m, applyRenameCmd(plan)
+	case "n", "N", "esc", "q":
+		m.renamePlan = nil
+		m.status = "已取消重命名。"
+		return m, nil
+	}
+
+	var cmd tea.Cmd
+	m.diffView, cmd = m.diffView.Update(msg)
+	return m, cmd
+}
+
+func (m *Model) resizeDiffView() {
+	width := m.width - 4
+	if width < 20 {
+		width = 80
+	}
+	height := m.height - 10
+	if height < 5 {
+		height = 20
+	}
+	m.diffView.Width = width
+	m.diffView.Height = height
+}
+
 func (m *Model) focusOnResults() bool {
-	return !m.loading && len(m.suggestions) > 0
+	return !m.loading && len(m.candidates()) > 0
 }
 
 // View 渲染界面。
@@ -162,10 +386,21 @@ func (m *Model) View() string {
 
 	sections = append(sections, titleStyle.Render("🌱 Name Sprout"))
 
+	if m.renamePlan != nil {
+		return m.renderRenamePreview(sections)
+	}
+	if m.history.open {
+		return m.renderHistory(sections)
+	}
+
 	toggle := "▶ 详情 (I)"
 	if m.showDetails {
 		toggle = "▼ 详情 (I)"
 	}
+	// 请求 ID 不随详情折叠，始终显示在这一行。
+	if m.requestID > 0 {
+		toggle += fmt.Sprintf("  请求 #%d", m.requestID)
+	}
 	sections = append(sections, faintStyle.Render(toggle))
 
 	if m.showDetails {
@@ -208,26 +443,64 @@ func (m *Model) View() string {
 		}
 	}
 
-	if m.focusOnResults() {
-		var rows []string
-		for i, name := range m.suggestions {
-			prefix := "  "
-			style := listItemStyle
-			if i == m.cursor {
-				prefix = "▶ "
-				style = selectedItemStyle
-			}
-			rows = append(rows, prefix+style.Render(name))
-		}
-		sections = append(sections, strings.Join(rows, "\n"))
-	} else if !m.loading && len(m.suggestions) == 0 {
+	sections = append(sections, m.renderCandidates()...)
+	if !m.loading && len(m.candidates()) == 0 {
 		sections = append(sections, errStyle.Render("未获取到任何候选结果。"))
 	}
 
-	help := faintStyle.Render("操作：↑↓ 选择  Enter/C 复制  R 重新生成  I 切换详情  Q 退出")
-	sections = append(sections, help)
+	keys := []string{"↑↓ 选择", "Enter/C 复制"}
+	if m.printMode {
+		keys = []string{"↑↓ 选择", "Enter 选定并退出", "C 复制"}
+	}
+	keys = append(keys, "Tab 多选", "Y 复制为…", "Space 固定")
+	if m.renameTarget != nil {
+		keys = append(keys, "N 重命名源码")
+	}
+	keys = append(keys, "R 重新生成", "X 导出")
+	if m.store != nil {
+		keys = append(keys, "H 历史")
+	}
+	if m.loading {
+		keys = append(keys, "Esc 取消生成")
+	}
+	keys = append(keys, "I 切换详情", "Q 退出")
+	sections = append(sections, faintStyle.Render("操作："+strings.Join(keys, "  ")))
 
-	return lipgloss.NewStyle().Padding(1, 2).Render(strings.Join(sections, "\n\n"))
+	return containerStyle.Render(strings.Join(sections, "\n\n"))
+}
+
+func (m *Model) renderRenamePreview(sections []string) string {
+	plan := m.renamePlan
+	sections = append(sections, fmt.Sprintf(
+		"重命名预览：%s → %s（%d 处引用，%d 个文件）",
+		infoStyle.Render(plan.OldName),
+		infoStyle.Render(plan.NewName),
+		plan.References,
+		len(plan.Files),
+	))
+	for _, warning := range plan.Warnings {
+		sections = append(sections, errStyle.Render("⚠ "+warning))
+	}
+	sections = append(sections, m.diffView.View())
+	sections = append(sections, faintStyle.Render("操作：Y 写入源码  N/Esc 取消  ↑↓ 滚动"))
+	return containerStyle.Render(strings.Join(sections, "\n\n"))
+}
+
+func renderDiff(diff string) string {
+	lines := strings.Split(strings.TrimRight(diff, "\n"), "\n")
+	for i, line := range lines {
+		switch {
+		case strings.HasPrefix(line, "+++"), strings.HasPrefix(line, "---"):
+			lines[i] = infoStyle.Render(line)
+		case strings.HasPrefix(line, "@@"):
+			lines[i] = faintStyle.Render(line)
+		case strings.HasPrefix(line, "+"):
+			lines[i] = diffAddStyle.Render(line)
+		case strings.HasPrefix(line, "-"):
+			lines[i] = errStyle.Render(line)
+		}
+	}
+	return strings.Join(lines, "\n")
 }
 
 func (m *Model) modelDisplay() string {
@@ -259,33 +532,83 @@ func (m *Model) moveCursor(delta int) {
 	if !m.focusOnResults() {
 		return
 	}
+	count := len(m.candidates())
 	m.cursor += delta
 	if m.cursor < 0 {
-		m.cursor = len(m.suggestions) - 1
-	} else if m.cursor >= len(m.suggestions) {
+		m.cursor = count - 1
+	} else if m.cursor >= count {
 		m.cursor = 0
 	}
 }
 
+// Chosen 返回 PrintOnSelect 模式下选中的名称，未选择即退出时为空。
+func (m *Model) Chosen() string {
+	return m.chosen
+}
+
+// choose 记录选中的名称并退出程序。
+func (m *Model) choose() tea.Cmd {
+	item, _ := m.selected()
+	m.chosen = item.name
+	if item.entryID != "" {
+		_ = m.store.MarkCopied(item.entryID, item.name)
+	}
+	return tea.Quit
+}
+
 func (m *Model) copySelected() tea.Cmd {
 	if !m.focusOnResults() {
 		return nil
 	}
-	name := m.suggestions[m.cursor]
-	if err := clipboard.WriteAll(name); err != nil {
-		m.err = fmt.Errorf("复制失败: %w", err)
+	item, _ := m.selected()
+	hint, err := m.writeClipboard(item.name, item)
+	if err != nil {
+		m.err = err
 		return nil
 	}
-	m.status = fmt.Sprintf("已复制：%s", name)
+	m.status = fmt.Sprintf("已复制：%s", item.name) + hint
 	return nil
 }
 
-func generateCmd(p providers.Provider, req providers.Request) tea.Cmd {
+// writeClipboard 写入剪贴板，并在历史中记录被复制的候选。返回值为附加在状态栏的复制方式说明。
+func (m *Model) writeClipboard(text string, copied ...candidate) (string, error) {
+	mode, err := m.clipboard.Write(text)
+	if err != nil {
+		return "", fmt.Errorf("复制失败: %w", err)
+	}
+	for _, item := range copied {
+		if item.entryID != "" {
+			_ = m.store.MarkCopied(item.entryID, item.name)
+		}
+	}
+	switch mode {
+	case clipboard.ModeOSC52:
+		return "（已通过 OSC 52 发送到终端）", nil
+	case clipboard.ModeStdout:
+		return "（退出后输出到标准输出）", nil
+	}
+	return "", nil
+}
+
+// cancelGeneration 取消进行中的生成请求。调用方随后要么清除 loading，要么发起带新请求 ID 的生成，
+// 因此被取消请求迟到的结果都会被忽略，请求 ID 也保持连续。
+func (m *Model) cancelGeneration() {
+	if m.cancel != nil {
+		m.cancel()
+		m.cancel = nil
+	}
+}
+
+func prepareRenameCmd(target *codectx.Snippet, newName string) tea.Cmd {
+	return func() tea.Msg {
+		plan, err := rename.Prepare(target.Path, target.Line, target.Column, newName)
+		return renamePlanMsg{plan: plan, err: err}
+	}
+}
+
+func applyRenameCmd(plan *rename.Plan) tea.Cmd {
 	return func() tea.Msg {
-		ctx, cancel := context.WithTimeout(context.Background(), defaultTimeout)
-		defer cancel()
-		names, err := p.GenerateNames(ctx, req)
-		return suggestionsMsg{names: names, err: err}
+		return renameAppliedMsg{plan: plan, err: plan.Apply()}
 	}
 }
 
@@ -301,10 +624,12 @@ func warmupProvider(p providers.Provider) error {
 }
 
 var (
+	containerStyle    = lipgloss.NewStyle().Padding(1, 2)
 	titleStyle        = lipgloss.NewStyle().Foreground(lipgloss.Color("205")).Bold(true)
 	infoStyle         = lipgloss.NewStyle().Foreground(lipgloss.Color("111")).Bold(true)
 	faintStyle        = lipgloss.NewStyle().Foreground(lipgloss.Color("240"))
 	errStyle          = lipgloss.NewStyle().Foreground(lipgloss.Color("203")).Bold(true)
 	listItemStyle     = lipgloss.NewStyle().Foreground(lipgloss.Color("247"))
+	diffAddStyle      = lipgloss.NewStyle().Foreground(lipgloss.Color("114"))
 	selectedItemStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("229")).Background(lipgloss.Color("63")).Bold(true)
 )
@@ -0,0 +1,113 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+)
+
+// candidate 是列表中的一项，固定的名称排在本轮生成结果之前。
+type candidate struct {
+	name    string
+	entryID string
+	pinned  bool
+}
+
+// candidates 返回当前可选的名称：先是固定的名称，再是本轮生成且未被固定的名称。
+func (m *Model) candidates() []candidate {
+	items := make([]candidate, 0, len(m.pinned)+len(m.suggestions))
+	seen := make(map[string]bool, len(m.pinned))
+	for _, item := range m.pinned {
+		items = append(items, item)
+		seen[item.name] = true
+	}
+	for _, name := range m.suggestions {
+		if seen[name] {
+			continue
+		}
+		items = append(items, candidate{name: name, entryID: m.entryID})
+	}
+	return items
+}
+
+func (m *Model) selected() (candidate, bool) {
+	items := m.candidates()
+	if m.cursor < 0 || m.cursor >= len(items) {
+		return candidate{}, false
+	}
+	return items[m.cursor], true
+}
+
+// togglePin 固定或取消固定光标所在的名称，固定的名称在重新生成后依然保留。
+func (m *Model) togglePin() {
+	item, ok := m.selected()
+	if !ok {
+		return
+	}
+	if item.pinned {
+		for i := range m.pinned {
+			if m.pinned[i].name == item.name {
+				m.pinned = append(m.pinned[:i], m.pinned[i+1:]...)
+				break
+			}
+		}
+		m.status = fmt.Sprintf("已取消固定：%s", item.name)
+	} else {
+		item.pinned = true
+		m.pinned = append(m.pinned, item)
+		m.status = fmt.Sprintf("已固定：%s", item.name)
+	}
+	if item.entryID != "" {
+		_ = m.store.MarkPinned(item.entryID, item.name, item.pinned)
+	}
+
+	// 光标跟随被操作的名称，避免列表重排后跳到别的候选上。
+	for i, other := range m.candidates() {
+		if other.name == item.name {
+			m.cursor = i
+			break
+		}
+	}
+}
+
+// renderCandidates 分“已固定”和“候选”两段渲染列表，生成中不显示光标。
+func (m *Model) renderCandidates() []string {
+	items := m.candidates()
+	var pinnedRows, rows []string
+	for i, item := range items {
+		prefix := "  "
+		style := listItemStyle
+		if i == m.cursor && !m.loading {
+			prefix = "▶ "
+			style = selectedItemStyle
+		}
+		if len(m.marked) > 0 {
+			if m.marked[item.name] {
+				prefix += "◉ "
+			} else {
+				prefix += "○ "
+			}
+		}
+		row := prefix + style.Render(item.name)
+		if m.collisions.Contains(item.name) {
+			row += " " + errStyle.Render("（已存在）")
+		}
+		if item.pinned {
+			pinnedRows = append(pinnedRows, row)
+		} else {
+			rows = append(rows, row)
+		}
+	}
+
+	var sections []string
+	if len(pinnedRows) > 0 {
+		sections = append(sections, infoStyle.Render("📌 已固定")+"\n"+strings.Join(pinnedRows, "\n"))
+	}
+	if len(rows) > 0 {
+		if len(pinnedRows) > 0 {
+			sections = append(sections, infoStyle.Render("候选")+"\n"+strings.Join(rows, "\n"))
+		} else {
+			sections = append(sections, strings.Join(rows, "\n"))
+		}
+	}
+	return sections
+}
@@ -0,0 +1,494 @@
+package ui
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/spinner"
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/yanzzp/name-sprout/internal/providers"
+)
+
+// SetupAnswers 为 init 向导收集到的配置项。
+type SetupAnswers struct {
+	ProviderType string
+	// APIKey 可以是密钥本身，也可以是 ${ENV} 引用或 !cmd 密钥命令，为空时由提供方读取默认环境变量。
+	APIKey string
+	// Model 为空时使用提供方的默认模型。
+	Model string
+	Style providers.NamingStyle
+}
+
+// SetupOptions 配置 init 向导。
+type SetupOptions struct {
+	// Target 为将要写入的配置文件路径，仅用于展示。
+	Target string
+	// Apply 在确认后于后台执行，负责写入配置并校验。
+	Apply func(ctx context.Context, answers SetupAnswers) error
+	// ListModels 可选，填写 API Key 后用于获取可选模型；返回空列表或出错时改为手动输入。
+	ListModels func(ctx context.Context, answers SetupAnswers) ([]providers.ModelInfo, error)
+}
+
+type setupStep int
+
+const (
+	stepProvider setupStep = iota
+	stepAPIKey
+	stepModel
+	stepStyle
+	stepConfirm
+	stepApplying
+	stepDone
+)
+
+type setupAppliedMsg struct {
+	err error
+}
+
+type setupModelsMsg struct {
+	models []providers.ModelInfo
+	err    error
+}
+
+// modelWindow 为模型列表一次展示的行数。
+const modelWindow = 10
+
+// SetupModel 是 `namesprout init` 的交互式配置向导。
+type SetupModel struct {
+	opts    SetupOptions
+	step    setupStep
+	types   []string
+	styles  []providers.NamingStyle
+	cursor  int
+	answers SetupAnswers
+
+	keyInput   textinput.Model
+	modelInput textinput.Model
+	spinner    spinner.Model
+
+	// models 为提供方返回的可选模型，为空时在模型步骤直接手动输入。
+	models        []providers.ModelInfo
+	modelsLoading bool
+	modelsErr     error
+	manualModel   bool
+
+	err       error
+	completed bool
+}
+
+// NewSetupModel 创建配置向导，提供方类型来自已注册的 Provider。
+func NewSetupModel(opts SetupOptions) *SetupModel {
+	keyInput := textinput.New()
+	keyInput.Placeholder = "API Key、${GEMINI_API_KEY} 或 !cmd \"pass show gemini\""
+	keyInput.Prompt = "› "
+
+	modelInput := textinput.New()
+	modelInput.Placeholder = "留空使用提供方默认模型"
+	modelInput.Prompt = "› "
+
+	sp := spinner.New()
+	sp.Spinner = spinner.Dot
+	sp.Style = lipgloss.NewStyle().Foreground(lipgloss.Color("205"))
+
+	return &SetupModel{
+		opts:       opts,
+		types:      providers.Types(),
+		styles:     providers.AllNamingStyles,
+		keyInput:   keyInput,
+		modelInput: modelInput,
+		spinner:    sp,
+	}
+}
+
+// Completed 表示配置已写入且校验通过。
+func (m *SetupModel) Completed() bool {
+	return m.completed
+}
+
+// Err 返回写入或校验配置时的错误。
+func (m *SetupModel) Err() error {
+	return m.err
+}
+
+// Init 实现 tea.Model。
+func (m *SetupModel) Init() tea.Cmd {
+	return nil
+}
+
+// Update 处理 Bubble Tea 消息。
+func (m *SetupModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		return m.handleKey(msg)
+	case setupAppliedMsg:
+		m.step = stepDone
+		m.err = msg.err
+		m.completed = msg.err == nil
+		return m, nil
+	case setupModelsMsg:
+		if !m.modelsLoading {
+			return m, nil
+		}
+		m.modelsLoading = false
+		m.models, m.modelsErr = msg.models, msg.err
+		if len(m.models) == 0 {
+			m.manualModel = true
+			return m, m.modelInput.Focus()
+		}
+		m.cursor = 0
+		return m, nil
+	}
+
+	if m.step == stepApplying || m.modelsLoading {
+		var cmd tea.Cmd
+		m.spinner, cmd = m.spinner.Update(msg)
+		return m, cmd
+	}
+	return m, nil
+}
+
+func (m *SetupModel) handleKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	key := msg.String()
+	if key == "ctrl+c" {
+		return m, tea.Quit
+	}
+
+	switch m.step {
+	case stepApplying:
+		return m, nil
+	case stepDone:
+		return m, tea.Quit
+	case stepProvider, stepStyle:
+		count := len(m.types)
+		if m.step == stepStyle {
+			count = len(m.styles)
+		}
+		switch key {
+		case "up":
+			if count > 0 {
+				m.cursor = (m.cursor - 1 + count) % count
+			}
+		case "down":
+			if count > 0 {
+				m.cursor = (m.cursor + 1) % count
+			}
+		case "enter":
+			return m, m.next()
+		case "esc":
+			return m, m.back()
+		}
+		return m, nil
+	case stepConfirm:
+		switch key {
+		case "enter", "y", "Y":
+			return m, m.next()
+		case "esc", "n", "N":
+			return m, m.back()
+		}
+		return m, nil
+	case stepModel:
+		if m.modelsLoading {
+			if key == "esc" {
+				return m, m.back()
+			}
+			return m, nil
+		}
+		if !m.manualModel {
+			// 选项依次为默认模型、提供方返回的模型与手动输入。
+			count := len(m.models) + 2
+			switch key {
+			case "up":
+				m.cursor = (m.cursor - 1 + count) % count
+			case "down":
+				m.cursor = (m.cursor + 1) % count
+			case "enter":
+				return m, m.next()
+			case "esc":
+				return m, m.back()
+			}
+			return m, nil
+		}
+	}
+
+	switch key {
+	case "enter":
+		return m, m.next()
+	case "esc":
+		return m, m.back()
+	}
+
+	input := &m.keyInput
+	if m.step == stepModel {
+		input = &m.modelInput
+	}
+	var cmd tea.Cmd
+	*input, cmd = input.Update(msg)
+	if m.step == stepAPIKey {
+		m.updateKeyEcho()
+	}
+	return m, cmd
+}
+
+// updateKeyEcho 直接输入密钥时隐藏明文，引用环境变量或密钥命令时正常显示。
+func (m *SetupModel) updateKeyEcho() {
+	value := strings.TrimSpace(m.keyInput.Value())
+	if value == "" || strings.HasPrefix(value, "$") || strings.HasPrefix(value, "!") {
+		m.keyInput.EchoMode = textinput.EchoNormal
+	} else {
+		m.keyInput.EchoMode = textinput.EchoPassword
+	}
+}
+
+func (m *SetupModel) next() tea.Cmd {
+	switch m.step {
+	case stepProvider:
+		if len(m.types) == 0 {
+			m.err = errors.New("没有已注册的提供方类型")
+			m.step = stepDone
+			return nil
+		}
+		m.answers.ProviderType = m.types[m.cursor]
+		m.step = stepAPIKey
+		return m.keyInput.Focus()
+	case stepAPIKey:
+		m.answers.APIKey = strings.TrimSpace(m.keyInput.Value())
+		m.keyInput.Blur()
+		m.step = stepModel
+		m.models, m.modelsErr = nil, nil
+		if m.opts.ListModels == nil {
+			m.manualModel = true
+			return m.modelInput.Focus()
+		}
+		m.manualModel = false
+		m.modelsLoading = true
+		return tea.Batch(m.spinner.Tick, m.listModelsCmd())
+	case stepModel:
+		if !m.manualModel {
+			switch {
+			case m.cursor == 0:
+				m.answers.Model = ""
+			case m.cursor <= len(m.models):
+				m.answers.Model = m.models[m.cursor-1].ID
+			default:
+				m.manualModel = true
+				return m.modelInput.Focus()
+			}
+		} else {
+			m.answers.Model = strings.TrimSpace(m.modelInput.Value())
+			m.modelInput.Blur()
+		}
+		m.step = stepStyle
+		m.cursor = 0
+	case stepStyle:
+		m.answers.Style = m.styles[m.cursor]
+		m.step = stepConfirm
+	case stepConfirm:
+		m.step = stepApplying
+		return tea.Batch(m.spinner.Tick, m.applyCmd())
+	}
+	return nil
+}
+
+func (m *SetupModel) back() tea.Cmd {
+	switch m.step {
+	case stepProvider:
+		return tea.Quit
+	case stepAPIKey:
+		m.keyInput.Blur()
+		m.step = stepProvider
+		m.cursor = indexOf(m.types, m.answers.ProviderType)
+	case stepModel:
+		m.modelInput.Blur()
+		m.modelsLoading = false
+		if m.manualModel && len(m.models) > 0 {
+			// 从手动输入返回到模型列表。
+			m.manualModel = false
+			m.cursor = len(m.models) + 1
+			return nil
+		}
+		m.step = stepAPIKey
+		return m.keyInput.Focus()
+	case stepStyle:
+		m.step = stepModel
+		if m.manualModel {
+			return m.modelInput.Focus()
+		}
+		m.cursor = 0
+		for i, model := range m.models {
+			if model.ID == m.answers.Model {
+				m.cursor = i + 1
+			}
+		}
+	case stepConfirm:
+		m.step = stepStyle
+		for i, style := range m.styles {
+			if style == m.answers.Style {
+				m.cursor = i
+			}
+		}
+	}
+	return nil
+}
+
+func (m *SetupModel) listModelsCmd() tea.Cmd {
+	list, answers := m.opts.ListModels, m.answers
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), initTimeout)
+		defer cancel()
+		models, err := list(ctx, answers)
+		return setupModelsMsg{models: models, err: err}
+	}
+}
+
+func (m *SetupModel) applyCmd() tea.Cmd {
+	apply, answers := m.opts.Apply, m.answers
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), defaultTimeout)
+		defer cancel()
+		return setupAppliedMsg{err: apply(ctx, answers)}
+	}
+}
+
+// View 渲染向导界面。
+func (m *SetupModel) View() string {
+	sections := []string{titleStyle.Render("🌱 Name Sprout 初始化")}
+	if m.opts.Target != "" {
+		sections = append(sections, faintStyle.Render("配置文件："+m.opts.Target))
+	}
+
+	switch m.step {
+	case stepProvider:
+		labels := make([]string, len(m.types))
+		for i, providerType := range m.types {
+			labels[i] = fmt.Sprintf("%s (%s)", providers.DisplayName(providerType), providerType)
+		}
+		sections = append(sections, infoStyle.Render("1/4 选择模型提供方"), renderChoices(labels, m.cursor))
+	case stepAPIKey:
+		sections = append(sections,
+			infoStyle.Render("2/4 API Key"),
+			faintStyle.Render("可直接输入密钥，或用 ${ENV_VAR} 引用环境变量、!cmd \"...\" 调用密钥管理工具；留空时 Gemini 读取 GEMINI_API_KEY / GOOGLE_API_KEY。"),
+			m.keyInput.View(),
+		)
+	case stepModel:
+		sections = append(sections, infoStyle.Render("3/4 模型"))
+		switch {
+		case m.modelsLoading:
+			sections = append(sections, fmt.Sprintf("%s 正在获取可用模型...", m.spinner.View()))
+		case !m.manualModel:
+			labels := make([]string, 0, len(m.models)+2)
+			labels = append(labels, "默认模型")
+			for _, model := range m.models {
+				label := model.ID
+				if model.DisplayName != "" {
+					label += faintStyle.Render("  " + model.DisplayName)
+				}
+				labels = append(labels, label)
+			}
+			labels = append(labels, "手动输入…")
+			sections = append(sections, renderChoiceWindow(labels, m.cursor, modelWindow))
+		default:
+			if m.modelsErr != nil {
+				sections = append(sections, faintStyle.Render("获取模型列表失败，请手动输入："+m.modelsErr.Error()))
+			}
+			sections = append(sections, m.modelInput.View())
+		}
+	case stepStyle:
+		labels := make([]string, len(m.styles))
+		for i, style := range m.styles {
+			labels[i] = string(style)
+		}
+		sections = append(sections, infoStyle.Render("4/4 函数与变量的命名格式"), renderChoices(labels, m.cursor))
+	case stepConfirm:
+		model := m.answers.Model
+		if model == "" {
+			model = "（默认）"
+		}
+		key := "（读取环境变量）"
+		if m.answers.APIKey != "" {
+			key = maskSecret(m.answers.APIKey)
+		}
+		sections = append(sections, infoStyle.Render("确认配置"), strings.Join([]string{
+			"提供方：" + m.answers.ProviderType,
+			"API Key：" + key,
+			"模型：" + model,
+			"默认命名格式：" + string(m.answers.Style),
+		}, "\n"))
+	case stepApplying:
+		sections = append(sections, fmt.Sprintf("%s 正在写入配置并向提供方发起一次测试生成...", m.spinner.View()))
+	case stepDone:
+		if m.err != nil {
+			sections = append(sections, errStyle.Render(m.err.Error()))
+		} else {
+			sections = append(sections, infoStyle.Render("✓ 配置已写入，测试生成成功，现在可以运行 namesprout -f \"描述\" 开始使用。"))
+		}
+	}
+
+	help := "操作：↑↓ 选择  Enter 下一步  Esc 返回  Ctrl+C 退出"
+	switch m.step {
+	case stepModel:
+		if m.modelsLoading {
+			help = "操作：Esc 返回  Ctrl+C 退出"
+		} else if m.manualModel {
+			help = "操作：Enter 下一步  Esc 返回  Ctrl+C 退出"
+		}
+	case stepAPIKey:
+		help = "操作：Enter 下一步  Esc 返回  Ctrl+C 退出"
+	case stepConfirm:
+		help = "操作：Enter/Y 写入  Esc/N 返回修改  Ctrl+C 退出"
+	case stepApplying:
+		help = ""
+	case stepDone:
+		help = "按任意键退出"
+	}
+	if help != "" {
+		sections = append(sections, faintStyle.Render(help))
+	}
+	return containerStyle.Render(strings.Join(sections, "\n\n"))
+}
+
+// renderChoiceWindow 只渲染光标附近的 size 行，避免长列表超出终端高度。
+func renderChoiceWindow(labels []string, cursor, size int) string {
+	if len(labels) <= size {
+		return renderChoices(labels, cursor)
+	}
+	start := min(max(cursor-size/2, 0), len(labels)-size)
+	rows := renderChoices(labels[start:start+size], cursor-start)
+	return rows + "\n" + faintStyle.Render(fmt.Sprintf("  （%d/%d）", cursor+1, len(labels)))
+}
+
+func renderChoices(labels []string, cursor int) string {
+	rows := make([]string, len(labels))
+	for i, label := range labels {
+		if i == cursor {
+			rows[i] = "▶ " + selectedItemStyle.Render(label)
+		} else {
+			rows[i] = "  " + listItemStyle.Render(label)
+		}
+	}
+	return strings.Join(rows, "\n")
+}
+
+// maskSecret 只显示密钥首尾少量字符，引用环境变量或密钥命令时原样显示。
+func maskSecret(value string) string {
+	if strings.HasPrefix(value, "$") || strings.HasPrefix(value, "!") {
+		return value
+	}
+	if len(value) <= 8 {
+		return strings.Repeat("*", len(value))
+	}
+	return value[:4] + strings.Repeat("*", 4) + value[len(value)-4:]
+}
+
+func indexOf(values []string, target string) int {
+	for i, value := range values {
+		if value == target {
+			return i
+		}
+	}
+	return 0
+}
@@ -0,0 +1,9 @@
+// Package prompts 内嵌默认的命名提示文件，使二进制脱离仓库目录也能获得完整的命名格式定义。
+package prompts
+
+import _ "embed"
+
+// Naming 为内嵌的 naming.yaml 原文。
+//
+//go:embed naming.yaml
+var Naming []byte